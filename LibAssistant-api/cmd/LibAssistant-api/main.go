@@ -1,100 +1,203 @@
 package main
 
 import (
+	issuegrpc "LibAssistant_api/internal/clients/issue/grpc"
 	ssogrpc "LibAssistant_api/internal/clients/sso/grpc"
 	"LibAssistant_api/internal/config"
-	isAdmin "LibAssistant_api/internal/http-server/handlers/auth/IsAdmin"
-	"LibAssistant_api/internal/http-server/handlers/auth/login"
-	"LibAssistant_api/internal/http-server/handlers/auth/register"
-	"LibAssistant_api/internal/http-server/handlers/auth/registerAsAdmin"
+	"LibAssistant_api/internal/http-server/handlers/health"
+	MWAuthz "LibAssistant_api/internal/http-server/middleware/authz"
+	MWCors "LibAssistant_api/internal/http-server/middleware/cors"
 	MWLogger "LibAssistant_api/internal/http-server/middleware/logger"
-	"LibAssistant_api/internal/lib/logger/handlers/slogpretty"
+	MWMetrics "LibAssistant_api/internal/http-server/middleware/metrics"
+	MWRateLimit "LibAssistant_api/internal/http-server/middleware/ratelimit"
+	MWTracing "LibAssistant_api/internal/http-server/middleware/tracing"
+	"LibAssistant_api/internal/lib/api/problem"
 	"LibAssistant_api/internal/lib/logger/sl"
+	"LibAssistant_api/internal/metrics"
+	"LibAssistant_lib/configcheck"
+	"LibAssistant_lib/logger"
+	"LibAssistant_lib/tracing"
 	"context"
+	"errors"
 	"log/slog"
 	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
-)
-
-const (
-	envLocal = "local"
-	envProd  = "prod"
-	envDev   = "dev"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "validate" {
+		os.Args = append(os.Args[:1], os.Args[2:]...)
+		os.Exit(configcheck.Run(os.Stdout, os.Stderr, config.LoadFromFlags, (*config.Config).Redacted))
+	}
+
 	cfg := config.MustLoad()
 
-	log := setupLogger(cfg.Env)
+	level := &slog.LevelVar{}
+	logger.SetLevel(level, cfg.Env, cfg.LogLevel)
+
+	log := logger.Setup(cfg.Env, level)
 
 	log.Info("starting application")
 
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing)
+	if err != nil {
+		log.Error("failed to set up tracing", sl.Err(err))
+		os.Exit(1)
+	}
+
+	m := metrics.New()
+
 	router := chi.NewRouter()
 
+	cors := MWCors.New(log, cfg.HTTPServer.CORS)
+
 	router.Use(middleware.RequestID)
 	router.Use(middleware.Logger)
+	router.Use(MWTracing.New(log))
 	router.Use(MWLogger.New(log))
+	router.Use(MWMetrics.New(log, m))
 	router.Use(middleware.Recoverer)
-	router.Use(middleware.URLFormat)
-
-	ssoClient, err := ssogrpc.New(context.Background(), log, cfg.Clients.SSO.Address, cfg.Clients.SSO.Timeout, cfg.Clients.SSO.RetriesCount)
+	// Before routing, so preflights for any route are answered here rather than 405.
+	router.Use(cors.Handler)
+
+	router.NotFound(func(w http.ResponseWriter, r *http.Request) {
+		problem.Write(w, r, problem.New(http.StatusNotFound, problem.CodeNotFound, "No such endpoint"))
+	})
+	router.MethodNotAllowed(func(w http.ResponseWriter, r *http.Request) {
+		problem.Write(w, r, problem.New(http.StatusMethodNotAllowed, problem.CodeMethodNotAllowed, "Method is not allowed for this endpoint"))
+	})
+
+	ssoClient, err := ssogrpc.New(context.Background(), log, cfg.Clients.SSO,
+		grpc.WithChainUnaryInterceptor(m.GRPC.UnaryClientInterceptor()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+	)
 	if err != nil {
 		log.Error("failed to init sso client", sl.Err(err))
 		os.Exit(1)
 	}
 
-	router.Post("/register", register.New(context.Background(), log, ssoClient))
-	router.Post("/login", login.New(context.Background(), log, ssoClient))
-	router.Post("/isAdmin", isAdmin.New(context.Background(), log, ssoClient))
-	router.Post("/registerAsAdmin", registerAsAdmin.New(context.Background(), log, ssoClient))
+	issueClient, err := issuegrpc.New(context.Background(), log, cfg.Clients.Issue,
+		grpc.WithChainUnaryInterceptor(m.GRPC.UnaryClientInterceptor()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+	)
+	if err != nil {
+		log.Error("failed to init issue client", sl.Err(err))
+		os.Exit(1)
+	}
+
+	readiness := &health.Readiness{}
+
+	rateStore := MWRateLimit.NewMemoryStore(time.Minute)
+	limiter := MWRateLimit.New(log, rateStore, cfg.HTTPServer.RateLimit)
+
+	api := &routes{
+		log:       log,
+		cfg:       cfg,
+		sso:       ssoClient,
+		issue:     issueClient,
+		authz:     MWAuthz.New(log, ssoClient, cfg.Authz.ClaimsMaxAge),
+		limiter:   limiter,
+		readiness: readiness,
+	}
+	api.mount(router)
+
+	var metricsSrv *http.Server
+	if cfg.Metrics.Address != "" {
+		metricsSrv = newMetricsServer(cfg.Metrics.Address, m)
+
+		go serve(log, "metrics server", metricsSrv)
+	}
 
 	srv := &http.Server{
-		Addr: cfg.HTTPServer.Address,
-		Handler: router,
-		ReadTimeout: cfg.HTTPServer.Timeout,
+		Addr:         cfg.HTTPServer.Address,
+		Handler:      router,
+		ReadTimeout:  cfg.HTTPServer.Timeout,
 		WriteTimeout: cfg.HTTPServer.Timeout,
-		IdleTimeout: cfg.HTTPServer.IdleTimeout,
+		IdleTimeout:  cfg.HTTPServer.IdleTimeout,
+	}
+
+	go serve(log, "http server", srv)
+
+	readiness.SetReady(true)
+
+	watcher := config.NewWatcher(log, cfg, func(next *config.Config) {
+		logger.SetLevel(level, next.Env, next.LogLevel)
+		ssoClient.SetRetryPolicy(next.Clients.SSO.Timeout, next.Clients.SSO.RetriesCount)
+		issueClient.SetRetryPolicy(next.Clients.Issue.Timeout, next.Clients.Issue.RetriesCount)
+		limiter.SetConfig(next.HTTPServer.RateLimit)
+		cors.SetConfig(next.HTTPServer.CORS)
+	})
+	go watcher.Watch(cfg.Reload.Interval)
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, syscall.SIGTERM, syscall.SIGINT)
+
+	sign := <-stop
+
+	log.Info("stopping application", slog.String("signal", sign.String()))
+
+	watcher.Stop()
+	rateStore.Stop()
+
+	// Fail readiness first and give load balancers time to notice before draining.
+	readiness.SetReady(false)
+	time.Sleep(cfg.HTTPServer.ShutdownDelay)
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.HTTPServer.ShutdownTimeout)
+	defer cancel()
+
+	if err := srv.Shutdown(ctx); err != nil {
+		log.Error("failed to drain http server", sl.Err(err))
 	}
 
-	if err := srv.ListenAndServe(); err != nil {
-		log.Error("failed to start server")
+	if metricsSrv != nil {
+		if err := metricsSrv.Shutdown(ctx); err != nil {
+			log.Error("failed to stop metrics server", sl.Err(err))
+		}
 	}
 
-	if err := srv.Shutdown(context.Background()); err != nil {
-		log.Error("failed to stop server")
+	if err := ssoClient.Close(); err != nil {
+		log.Error("failed to close sso client", sl.Err(err))
+	}
+
+	if err := issueClient.Close(); err != nil {
+		log.Error("failed to close issue client", sl.Err(err))
 	}
-}
 
-func setupLogger(env string) *slog.Logger {
-	var log *slog.Logger
-
-	switch env {
-	case envLocal:
-		log = setupPrettySlog()
-	case envDev:
-		log = slog.New(
-			slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}),
-		)
-	case envProd:
-		log = slog.New(
-			slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo}),
-		)
+	if err := shutdownTracing(ctx); err != nil {
+		log.Error("failed to flush traces", sl.Err(err))
 	}
 
-	return log
+	log.Info("application stopped")
 }
 
-func setupPrettySlog() *slog.Logger {
-	opts := slogpretty.PrettyHandlerOptions{
-		SlogOpts: &slog.HandlerOptions{
-			Level: slog.LevelDebug,
-		},
+// newMetricsServer exposes /metrics on its own listener, so it is not reachable through the public API.
+func newMetricsServer(addr string, m *metrics.Metrics) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(m.Registry, promhttp.HandlerOpts{Registry: m.Registry}))
+
+	return &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
 	}
+}
 
-	handler := opts.NewPrettyHandler(os.Stdout)
+// serve runs srv until Shutdown; any other failure to listen is fatal.
+func serve(log *slog.Logger, name string, srv *http.Server) {
+	log.Info(name+" is running", slog.String("addr", srv.Addr))
 
-	return slog.New(handler)
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Error(name+" failed", sl.Err(err))
+		os.Exit(1)
+	}
 }
@@ -0,0 +1,175 @@
+package main
+
+import (
+	issuegrpc "LibAssistant_api/internal/clients/issue/grpc"
+	ssogrpc "LibAssistant_api/internal/clients/sso/grpc"
+	"LibAssistant_api/internal/config"
+	isAdmin "LibAssistant_api/internal/http-server/handlers/auth/IsAdmin"
+	"LibAssistant_api/internal/http-server/handlers/auth/csrf"
+	"LibAssistant_api/internal/http-server/handlers/auth/login"
+	"LibAssistant_api/internal/http-server/handlers/auth/register"
+	"LibAssistant_api/internal/http-server/handlers/auth/registerAsAdmin"
+	"LibAssistant_api/internal/http-server/handlers/books/addBook"
+	"LibAssistant_api/internal/http-server/handlers/books/availability"
+	"LibAssistant_api/internal/http-server/handlers/books/getBook"
+	"LibAssistant_api/internal/http-server/handlers/debts/allDebts"
+	"LibAssistant_api/internal/http-server/handlers/debts/debtors"
+	"LibAssistant_api/internal/http-server/handlers/health"
+	"LibAssistant_api/internal/http-server/handlers/loans/issueBook"
+	"LibAssistant_api/internal/http-server/handlers/loans/reportLost"
+	"LibAssistant_api/internal/http-server/handlers/loans/returnBook"
+	MWAuthz "LibAssistant_api/internal/http-server/middleware/authz"
+	MWCsrf "LibAssistant_api/internal/http-server/middleware/csrf"
+	MWDeprecation "LibAssistant_api/internal/http-server/middleware/deprecation"
+	MWJwt "LibAssistant_api/internal/http-server/middleware/jwt"
+	MWRateLimit "LibAssistant_api/internal/http-server/middleware/ratelimit"
+	"LibAssistant_api/internal/lib/api/openapi"
+	"context"
+	"log/slog"
+	"net/http"
+	"path"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// legacyDeprecatedSince is when the unversioned routes were replaced by /api/v1.
+var legacyDeprecatedSince = time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)
+
+// routes is what the gateway's endpoints are served with.
+type routes struct {
+	log       *slog.Logger
+	cfg       *config.Config
+	sso       *ssogrpc.Client
+	issue     *issuegrpc.Client
+	authz     *MWAuthz.Authorizer
+	limiter   *MWRateLimit.Limiter
+	readiness *health.Readiness
+}
+
+// mount registers every endpoint on router. Each one must also be listed in spec;
+// TestSpecMatchesRoutes fails otherwise.
+func (rt *routes) mount(router chi.Router) {
+	router.Use(legacyDownloads)
+
+	router.Get("/healthz", health.Live())
+	router.Get("/readyz", health.Ready(rt.readiness))
+
+	router.Get("/openapi.json", openapi.Handler(spec()))
+	router.Get("/docs", openapi.Docs("/openapi.json", "/docs/assets"))
+	router.Get("/docs/assets/{file}", openapi.Assets())
+
+	authenticated := chi.Middlewares{MWJwt.New(rt.log, rt.cfg.AppSecret), MWCsrf.New(rt.log, rt.cfg.AppSecret)}
+
+	var (
+		registerHandler        = register.New(context.Background(), rt.log, rt.sso)
+		loginHandler           = login.New(context.Background(), rt.log, rt.sso, rt.cfg.AppSecret)
+		registerAsAdminHandler = registerAsAdmin.New(context.Background(), rt.log, rt.sso)
+		csrfHandler            = csrf.New(context.Background(), rt.cfg.AppSecret)
+		isAdminHandler         = isAdmin.New(context.Background(), rt.log, rt.sso, rt.authz)
+		legacyIsAdminHandler   = isAdmin.NewLegacy(context.Background(), rt.log, rt.sso, rt.authz)
+		issueBookHandler       = issueBook.New(context.Background(), rt.log, rt.issue)
+		returnBookHandler      = returnBook.New(context.Background(), rt.log, rt.issue)
+		reportLostHandler      = reportLost.New(context.Background(), rt.log, rt.issue)
+		availabilityHandler    = availability.New(context.Background(), rt.log, rt.issue)
+		addBookHandler         = addBook.New(context.Background(), rt.log, rt.issue)
+		getBookHandler         = getBook.New(context.Background(), rt.log, rt.issue)
+		allDebtsHandler        = allDebts.New(context.Background(), rt.log, rt.issue)
+		debtorsHandler         = debtors.New(context.Background(), rt.log, rt.issue)
+	)
+
+	router.Route("/api/v1", func(r chi.Router) {
+		r.Route("/auth", func(r chi.Router) {
+			r.With(rt.limiter.Route("register")).Post("/register", registerHandler)
+			r.With(rt.limiter.Route("login")).Post("/login", loginHandler)
+			r.With(rt.limiter.Route("register_as_admin")).Post("/register-admin", registerAsAdminHandler)
+		})
+
+		r.Route("/me", func(r chi.Router) {
+			r.Use(authenticated...)
+
+			r.Get("/csrf", csrfHandler)
+			r.Get("/is-admin", isAdminHandler)
+		})
+
+		r.Route("/books", func(r chi.Router) {
+			r.Use(authenticated...)
+
+			r.Get("/{id}/availability", availabilityHandler)
+		})
+
+		r.Route("/loans", func(r chi.Router) {
+			r.Use(authenticated...)
+			r.Use(rt.authz.RequirePermissions(MWAuthz.PermLoansManage))
+
+			r.Post("/", issueBookHandler)
+			r.Post("/{id}/return", returnBookHandler)
+			r.Post("/{id}/lost", reportLostHandler)
+		})
+
+		r.Route("/admin", func(r chi.Router) {
+			// Lets downloads be linked as e.g. /debts.csv; not global, as it would
+			// turn /openapi.json into /openapi.
+			r.Use(middleware.URLFormat)
+			r.Use(authenticated...)
+
+			r.With(rt.authz.RequirePermissions(MWAuthz.PermUsersRead)).Get("/users/{id}/is-admin", isAdminHandler)
+
+			r.Group(func(r chi.Router) {
+				r.Use(rt.authz.RequirePermissions(MWAuthz.PermCatalogManage))
+
+				r.Post("/books", addBookHandler)
+				r.Get("/books/{id}", getBookHandler)
+			})
+
+			r.Group(func(r chi.Router) {
+				r.Use(rt.authz.RequirePermissions(MWAuthz.PermDebtsRead))
+
+				r.Get("/debts", allDebtsHandler)
+				r.Get("/debtors", debtorsHandler)
+			})
+		})
+	})
+
+	// Routes from before /api/v1, kept working for existing clients. The deprecation
+	// headers come first, so even failed requests carry them; rate limit buckets are
+	// shared with the successors, so using both does not double the allowance.
+	legacy := func(successor string, middlewares ...func(http.Handler) http.Handler) chi.Router {
+		return router.With(MWDeprecation.New(rt.log, legacyDeprecatedSince, "/api/v1"+successor)).With(middlewares...)
+	}
+	legacyAuthenticated := func(successor string, middlewares ...func(http.Handler) http.Handler) chi.Router {
+		return legacy(successor, authenticated...).With(middlewares...)
+	}
+
+	legacy("/auth/register", rt.limiter.Route("register")).Post("/register", registerHandler)
+	legacy("/auth/login", rt.limiter.Route("login")).Post("/login", loginHandler)
+	legacy("/auth/register-admin", rt.limiter.Route("register_as_admin")).Post("/registerAsAdmin", registerAsAdminHandler)
+
+	legacyAuthenticated("/me/csrf").Get("/csrf", csrfHandler)
+	legacyAuthenticated("/me/is-admin").Post("/isAdmin", legacyIsAdminHandler)
+	legacyAuthenticated("/loans", rt.authz.RequirePermissions(MWAuthz.PermLoansManage)).Post("/loans", issueBookHandler)
+	legacyAuthenticated("/books/{id}/availability").Get("/books/{id}/availability", availabilityHandler)
+	legacyAuthenticated("/loans/{id}/return", rt.authz.RequirePermissions(MWAuthz.PermLoansManage)).Post("/loans/{id}/return", returnBookHandler)
+	legacyAuthenticated("/loans/{id}/lost", rt.authz.RequirePermissions(MWAuthz.PermLoansManage)).Post("/loans/{id}/lost", reportLostHandler)
+	legacyAuthenticated("/admin/books", rt.authz.RequirePermissions(MWAuthz.PermCatalogManage)).Post("/books", addBookHandler)
+	legacyAuthenticated("/admin/books/{id}", rt.authz.RequirePermissions(MWAuthz.PermCatalogManage)).Get("/books/{id}", getBookHandler)
+	legacyAuthenticated("/admin/debts", rt.authz.RequirePermissions(MWAuthz.PermDebtsRead)).Get("/debts", allDebtsHandler)
+	legacyAuthenticated("/admin/debtors", rt.authz.RequirePermissions(MWAuthz.PermDebtsRead)).Get("/debtors", debtorsHandler)
+}
+
+// legacyDownloads lets the legacy debt listings be linked as e.g. /debts.csv, like
+// their successors under /api/v1/admin. It has to run before routing, but applied to
+// every path middleware.URLFormat would turn /openapi.json into /openapi.
+func legacyDownloads(next http.Handler) http.Handler {
+	withFormat := middleware.URLFormat(next)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch p := r.URL.Path; p[:len(p)-len(path.Ext(p))] {
+		case "/debts", "/debtors":
+			withFormat.ServeHTTP(w, r)
+		default:
+			next.ServeHTTP(w, r)
+		}
+	})
+}
@@ -0,0 +1,251 @@
+package main
+
+import (
+	"LibAssistant_api/internal/config"
+	"LibAssistant_api/internal/http-server/handlers/health"
+	MWAuthz "LibAssistant_api/internal/http-server/middleware/authz"
+	MWRateLimit "LibAssistant_api/internal/http-server/middleware/ratelimit"
+	jwtValidation "LibAssistant_api/internal/lib/jwt"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSpecMatchesRoutes fails when a route is registered without being documented
+// in spec, or documented without being registered.
+func TestSpecMatchesRoutes(t *testing.T) {
+	router := newTestRouter(t)
+
+	var registered []string
+	err := chi.Walk(router, func(method string, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		// Subrouter roots such as /api/v1/loans/ are matched without the slash too.
+		if len(route) > 1 {
+			route = strings.TrimSuffix(route, "/")
+		}
+
+		registered = append(registered, method+" "+route)
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	sort.Strings(registered)
+
+	assert.Equal(t, spec().Routes(), registered)
+}
+
+func TestSpecServed(t *testing.T) {
+	router := newTestRouter(t)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var doc struct {
+		OpenAPI    string                               `json:"openapi"`
+		Paths      map[string]map[string]map[string]any `json:"paths"`
+		Components struct {
+			Schemas map[string]any `json:"schemas"`
+		} `json:"components"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &doc))
+
+	assert.Equal(t, "3.0.3", doc.OpenAPI)
+	assert.Contains(t, doc.Components.Schemas, "login.Request")
+	assert.Contains(t, doc.Components.Schemas, "isAdmin.Response")
+	assert.Contains(t, doc.Components.Schemas, "problem.Problem")
+	assert.Equal(t, true, doc.Paths["/isAdmin"]["post"]["deprecated"])
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/docs", nil))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.True(t, strings.HasPrefix(rec.Header().Get("Content-Type"), "text/html"))
+	assert.Contains(t, rec.Body.String(), `url: "\/openapi.json"`)
+	assert.Contains(t, rec.Body.String(), `src="/docs/assets/swagger-ui-bundle.js"`)
+	assert.NotContains(t, rec.Body.String(), "https://", "the page loads nothing from other hosts")
+
+	for file, contentType := range map[string]string{
+		"swagger-ui-bundle.js": "text/javascript",
+		"swagger-ui.css":       "text/css",
+	} {
+		rec = httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/docs/assets/"+file, nil))
+
+		require.Equal(t, http.StatusOK, rec.Code, file)
+		assert.True(t, strings.HasPrefix(rec.Header().Get("Content-Type"), contentType), file)
+		assert.NotEmpty(t, rec.Body.Bytes(), file)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/docs/assets/README.md", nil))
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestSpecSchemas(t *testing.T) {
+	schemas := spec().Components.Schemas
+
+	login := schemas["login.Request"]
+	require.NotNil(t, login)
+	assert.Equal(t, []string{"email", "password"}, login.Required)
+	assert.Equal(t, "email", login.Properties["email"].Format)
+
+	// Embedded resp.Response is inlined like encoding/json does.
+	isAdminResp := schemas["isAdmin.Response"]
+	require.NotNil(t, isAdminResp)
+	assert.Contains(t, isAdminResp.Properties, "status")
+	assert.Equal(t, "boolean", isAdminResp.Properties["isAdmin"].Type)
+
+	issue := schemas["issueBook.Request"]
+	require.NotNil(t, issue)
+	assert.Equal(t, []string{"bookID", "studentID"}, issue.Required)
+	assert.Equal(t, 365.0, *issue.Properties["daysDue"].Maximum)
+
+	debt := schemas["debts.Debt"]
+	require.NotNil(t, debt)
+	assert.True(t, debt.Properties["dueDate"].Nullable)
+	assert.Equal(t, "date-time", debt.Properties["dueDate"].Format)
+}
+
+// TestLegacyRoutesDeprecated checks every legacy alias, even when the request fails,
+// announces its deprecation and links its successor.
+func TestLegacyRoutesDeprecated(t *testing.T) {
+	router := newTestRouter(t)
+
+	for _, l := range legacyEndpoints {
+		t.Run(l.method+" "+l.path, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(l.method, strings.ReplaceAll(l.path, "{id}", "7"), strings.NewReader("{}")))
+
+			_, successor, _ := strings.Cut(l.successor, " ")
+			successor = strings.ReplaceAll(successor, "{id}", "7")
+
+			assert.Equal(t, fmt.Sprintf("@%d", legacyDeprecatedSince.Unix()), rec.Header().Get("Deprecation"))
+			assert.Equal(t, `<`+successor+`>; rel="successor-version"`, rec.Header().Get("Link"))
+		})
+	}
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/auth/login", strings.NewReader("{}")))
+
+	assert.Empty(t, rec.Header().Get("Deprecation"))
+	assert.Empty(t, rec.Header().Get("Link"))
+}
+
+// TestLegacyDownloadExtensions checks the legacy debt listings, like their
+// successors, take the format from a path extension, while /openapi.json keeps its own.
+func TestLegacyDownloadExtensions(t *testing.T) {
+	router := newTestRouter(t)
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"uid":   int64(1),
+		"roles": []string{jwtValidation.RoleAdmin},
+		"iat":   time.Now().Unix(),
+		"exp":   time.Now().Add(time.Hour).Unix(),
+	}).SignedString([]byte("test-secret"))
+	require.NoError(t, err)
+
+	// An unsupported extension is refused before the issue service is called, which
+	// shows the extension reached the handler instead of failing to route.
+	for _, p := range []string{"/debts.pdf", "/debtors.pdf", "/api/v1/admin/debts.pdf", "/api/v1/admin/debtors.pdf"} {
+		t.Run(p, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, p, nil)
+			r.Header.Set("Authorization", "Bearer "+token)
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, r)
+
+			assert.Equal(t, http.StatusNotAcceptable, rec.Code)
+		})
+	}
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+// TestLegacyRoutesShareRateLimits checks a client cannot double its allowance by
+// alternating between a route and its legacy alias.
+func TestLegacyRoutesShareRateLimits(t *testing.T) {
+	tests := []struct {
+		route, v1, legacy string
+	}{
+		{route: "login", v1: "/api/v1/auth/login", legacy: "/login"},
+		{route: "register", v1: "/api/v1/auth/register", legacy: "/register"},
+		{route: "register_as_admin", v1: "/api/v1/auth/register-admin", legacy: "/registerAsAdmin"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.route, func(t *testing.T) {
+			cfg := &config.Config{AppSecret: "test-secret"}
+			cfg.HTTPServer.RateLimit = config.RateLimit{
+				Enabled: true,
+				Policies: map[string]config.RateLimitPolicy{
+					tt.route: {IP: config.Limit{Requests: 2, Per: time.Hour}},
+				},
+			}
+
+			router := newTestRouterWith(t, cfg)
+
+			post := func(path string) int {
+				// An empty body fails validation before any backend is called.
+				r := httptest.NewRequest(http.MethodPost, path, strings.NewReader("{}"))
+				r.RemoteAddr = "192.0.2.1:1234"
+
+				rec := httptest.NewRecorder()
+				router.ServeHTTP(rec, r)
+
+				return rec.Code
+			}
+
+			assert.Equal(t, http.StatusBadRequest, post(tt.v1))
+			assert.Equal(t, http.StatusBadRequest, post(tt.legacy))
+			assert.Equal(t, http.StatusTooManyRequests, post(tt.v1))
+			assert.Equal(t, http.StatusTooManyRequests, post(tt.legacy))
+		})
+	}
+}
+
+func newTestRouter(t *testing.T) *chi.Mux {
+	t.Helper()
+
+	return newTestRouterWith(t, &config.Config{AppSecret: "test-secret"})
+}
+
+func newTestRouterWith(t *testing.T, cfg *config.Config) *chi.Mux {
+	t.Helper()
+
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	store := MWRateLimit.NewMemoryStore(time.Hour)
+	t.Cleanup(store.Stop)
+
+	router := chi.NewRouter()
+
+	api := &routes{
+		log:       log,
+		cfg:       cfg,
+		authz:     MWAuthz.New(log, nil, time.Minute),
+		limiter:   MWRateLimit.New(log, store, cfg.HTTPServer.RateLimit),
+		readiness: &health.Readiness{},
+	}
+	api.mount(router)
+
+	return router
+}
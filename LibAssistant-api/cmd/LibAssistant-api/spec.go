@@ -0,0 +1,228 @@
+package main
+
+import (
+	isAdmin "LibAssistant_api/internal/http-server/handlers/auth/IsAdmin"
+	"LibAssistant_api/internal/http-server/handlers/auth/csrf"
+	"LibAssistant_api/internal/http-server/handlers/auth/login"
+	"LibAssistant_api/internal/http-server/handlers/auth/register"
+	"LibAssistant_api/internal/http-server/handlers/auth/registerAsAdmin"
+	"LibAssistant_api/internal/http-server/handlers/books/addBook"
+	"LibAssistant_api/internal/http-server/handlers/books/availability"
+	"LibAssistant_api/internal/http-server/handlers/books/getBook"
+	"LibAssistant_api/internal/http-server/handlers/debts"
+	"LibAssistant_api/internal/http-server/handlers/debts/allDebts"
+	"LibAssistant_api/internal/http-server/handlers/debts/debtors"
+	"LibAssistant_api/internal/http-server/handlers/loans/issueBook"
+	"LibAssistant_api/internal/http-server/handlers/loans/reportLost"
+	"LibAssistant_api/internal/http-server/handlers/loans/returnBook"
+	"LibAssistant_api/internal/lib/api/export"
+	"LibAssistant_api/internal/lib/api/openapi"
+	"LibAssistant_api/internal/lib/api/response"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+var debtsQuery = []openapi.Parameter{
+	openapi.QueryParam("student_id", "integer", "Only debts of this student."),
+	openapi.QueryParam("sort", "string", "Sort key; fine by default.", debts.SortFine, debts.SortOverdueDays),
+	openapi.QueryParam("order", "string", "Sort order; desc by default.", "asc", "desc"),
+	openapi.QueryParam("limit", "integer", "At most this many debts; 0 means all."),
+	openapi.QueryParam("format", "string", "Response format, overriding Accept; under /api/v1 a .csv or .xlsx path extension works too.",
+		string(export.JSON), string(export.CSV), string(export.XLSX)),
+}
+
+// v1Endpoints documents the routes under /api/v1, in the order mount registers them.
+var v1Endpoints = []openapi.Endpoint{
+	{
+		Method: http.MethodPost, Path: "/api/v1/auth/register", Tag: "auth",
+		Summary:     "Register a student",
+		RateLimited: true,
+		Request:     register.Request{},
+		Status:      http.StatusCreated, Response: register.Response{},
+		Errors: []int{http.StatusConflict},
+	},
+	{
+		Method: http.MethodPost, Path: "/api/v1/auth/login", Tag: "auth",
+		Summary:     "Log in",
+		Description: "Returns an access token and sets it as the auth_token cookie, together with the csrf_token cookie.",
+		RateLimited: true,
+		Request:     login.Request{},
+		Status:      http.StatusCreated, Response: login.Response{},
+		Errors: []int{http.StatusUnauthorized},
+	},
+	{
+		Method: http.MethodPost, Path: "/api/v1/auth/register-admin", Tag: "auth",
+		Summary:     "Register an admin",
+		RateLimited: true,
+		Request:     registerAsAdmin.Request{},
+		Status:      http.StatusCreated, Response: registerAsAdmin.Response{},
+		Errors: []int{http.StatusForbidden, http.StatusConflict},
+	},
+	{
+		Method: http.MethodGet, Path: "/api/v1/me/csrf", Tag: "me",
+		Summary:     "Get the CSRF token of the session",
+		Description: "Cookie-authenticated clients send it in the X-CSRF-Token header of state-changing requests.",
+		Auth:        true,
+		Response:    csrf.Response{},
+	},
+	{
+		Method: http.MethodGet, Path: "/api/v1/me/is-admin", Tag: "me",
+		Summary:  "Check whether the caller is an admin",
+		Auth:     true,
+		Response: isAdmin.Response{},
+	},
+	{
+		Method: http.MethodGet, Path: "/api/v1/books/{id}/availability", Tag: "books",
+		Summary:  "Get the available copies of a book",
+		Auth:     true,
+		Response: availability.Response{},
+		Errors:   []int{http.StatusNotFound},
+	},
+	{
+		Method: http.MethodPost, Path: "/api/v1/loans", Tag: "loans",
+		Summary:     "Issue a book to a student",
+		Description: "Needs the loans:manage permission. studentID is the issue service's student id.",
+		Auth:        true,
+		Request:     issueBook.Request{},
+		Status:      http.StatusCreated, Response: issueBook.Response{},
+		Errors: []int{http.StatusNotFound, http.StatusConflict},
+	},
+	{
+		Method: http.MethodPost, Path: "/api/v1/loans/{id}/return", Tag: "loans",
+		Summary:     "Return a borrowed book",
+		Description: "Needs the loans:manage permission.",
+		Auth:        true,
+		Response:    returnBook.Response{},
+		Errors:      []int{http.StatusNotFound, http.StatusConflict},
+	},
+	{
+		Method: http.MethodPost, Path: "/api/v1/loans/{id}/lost", Tag: "loans",
+		Summary:     "Report a borrowed book lost",
+		Description: "Needs the loans:manage permission.",
+		Auth:        true,
+		Response:    reportLost.Response{},
+		Errors:      []int{http.StatusNotFound, http.StatusConflict},
+	},
+	{
+		Method: http.MethodGet, Path: "/api/v1/admin/users/{id}/is-admin", Tag: "admin",
+		Summary:     "Check whether a user is an admin",
+		Description: "Needs the users:read permission.",
+		Auth:        true,
+		Response:    isAdmin.Response{},
+		Errors:      []int{http.StatusNotFound},
+	},
+	{
+		Method: http.MethodPost, Path: "/api/v1/admin/books", Tag: "admin",
+		Summary:     "Add a book to the catalog",
+		Description: "Needs the catalog:manage permission. The Location header points to the new book.",
+		Auth:        true,
+		Request:     addBook.Request{},
+		Status:      http.StatusCreated, Response: addBook.Response{},
+	},
+	{
+		Method: http.MethodGet, Path: "/api/v1/admin/books/{id}", Tag: "admin",
+		Summary:     "Get a catalog record",
+		Description: "Needs the catalog:manage permission.",
+		Auth:        true,
+		Response:    getBook.Response{},
+		Errors:      []int{http.StatusNotFound},
+	},
+	{
+		Method: http.MethodGet, Path: "/api/v1/admin/debts", Tag: "admin",
+		Summary:     "List every debt",
+		Description: "Needs the debts:read permission.",
+		Auth:        true,
+		Query:       debtsQuery,
+		Response:    allDebts.Response{},
+		Formats:     []string{export.ContentTypeCSV, export.ContentTypeXLSX},
+		Errors:      []int{http.StatusNotAcceptable},
+	},
+	{
+		Method: http.MethodGet, Path: "/api/v1/admin/debtors", Tag: "admin",
+		Summary:     "List the students with overdue books",
+		Description: "Needs the debts:read permission. The list may come from the issue service's cache.",
+		Auth:        true,
+		Query:       debtsQuery,
+		Response:    debtors.Response{},
+		Formats:     []string{export.ContentTypeCSV, export.ContentTypeXLSX},
+		Errors:      []int{http.StatusNotAcceptable},
+	},
+}
+
+// legacyEndpoints are the unversioned aliases, each documented like its successor.
+var legacyEndpoints = []struct {
+	method, path string
+	successor    string
+	request      any
+}{
+	{http.MethodPost, "/register", "POST /api/v1/auth/register", nil},
+	{http.MethodPost, "/login", "POST /api/v1/auth/login", nil},
+	{http.MethodPost, "/registerAsAdmin", "POST /api/v1/auth/register-admin", nil},
+	{http.MethodGet, "/csrf", "GET /api/v1/me/csrf", nil},
+	// Unlike its successor, it takes a body naming any user, which needs users:read.
+	{http.MethodPost, "/isAdmin", "GET /api/v1/me/is-admin", isAdmin.Request{}},
+	{http.MethodPost, "/loans", "POST /api/v1/loans", nil},
+	{http.MethodGet, "/books/{id}/availability", "GET /api/v1/books/{id}/availability", nil},
+	{http.MethodPost, "/loans/{id}/return", "POST /api/v1/loans/{id}/return", nil},
+	{http.MethodPost, "/loans/{id}/lost", "POST /api/v1/loans/{id}/lost", nil},
+	{http.MethodPost, "/books", "POST /api/v1/admin/books", nil},
+	{http.MethodGet, "/books/{id}", "GET /api/v1/admin/books/{id}", nil},
+	{http.MethodGet, "/debts", "GET /api/v1/admin/debts", nil},
+	{http.MethodGet, "/debtors", "GET /api/v1/admin/debtors", nil},
+}
+
+// spec documents every route mount registers.
+func spec() *openapi.Document {
+	endpoints := []openapi.Endpoint{
+		{Method: http.MethodGet, Path: "/healthz", Tag: "health", Summary: "Liveness probe", Response: resp.Response{}},
+		{Method: http.MethodGet, Path: "/readyz", Tag: "health", Summary: "Readiness probe", Response: resp.Response{}, Errors: []int{http.StatusServiceUnavailable}},
+		{Method: http.MethodGet, Path: "/openapi.json", Tag: "docs", Summary: "This document", Response: map[string]any{}},
+		{Method: http.MethodGet, Path: "/docs", Tag: "docs", Summary: "Interactive documentation", Formats: []string{"text/html"}},
+		{Method: http.MethodGet, Path: "/docs/assets/{file}", Tag: "docs", Summary: "Swagger UI scripts and styles", Formats: []string{"text/css", "text/javascript"}, Errors: []int{http.StatusNotFound}},
+	}
+
+	endpoints = append(endpoints, v1Endpoints...)
+
+	successors := make(map[string]openapi.Endpoint, len(v1Endpoints))
+	for _, e := range v1Endpoints {
+		successors[e.Method+" "+e.Path] = e
+	}
+
+	for _, l := range legacyEndpoints {
+		e, ok := successors[l.successor]
+		if !ok {
+			panic("spec: unknown successor " + l.successor)
+		}
+
+		e.Method, e.Path, e.Tag = l.method, l.path, "legacy"
+		e.Deprecated = true
+		e.Description = strings.TrimSpace(fmt.Sprintf("Deprecated alias of %s. %s", l.successor, e.Description))
+
+		if l.request != nil {
+			e.Request = l.request
+		}
+
+		endpoints = append(endpoints, e)
+	}
+
+	return openapi.Build(
+		openapi.Info{
+			Title:   "LibAssistant API",
+			Version: "1.0.0",
+			Description: "Gateway to the library services. Errors are RFC 7807 problem details " +
+				"with a stable code.",
+		},
+		[]openapi.Tag{
+			{Name: "auth", Description: "Registration and login"},
+			{Name: "me", Description: "The caller's session"},
+			{Name: "books", Description: "The catalog as students see it"},
+			{Name: "loans", Description: "Borrowing and returning books"},
+			{Name: "admin", Description: "Catalog and debt management; each route needs a permission"},
+			{Name: "legacy", Description: "Unversioned routes kept for old clients"},
+			{Name: "health", Description: "Probes"},
+			{Name: "docs", Description: "API documentation"},
+		},
+		endpoints,
+	)
+}
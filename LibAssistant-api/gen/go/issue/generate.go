@@ -0,0 +1,5 @@
+// Package issuev1 holds the Go bindings of the issue service API, generated from
+// LibAssistant-issue/protos/issue.proto.
+package issuev1
+
+//go:generate protoc -I ../../../../LibAssistant-issue/protos --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative issue.proto
@@ -0,0 +1,1126 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v6.33.1
+// source: issue.proto
+
+package issuev1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type IssueRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BookId        int64                  `protobuf:"varint,1,opt,name=book_id,json=bookId,proto3" json:"book_id,omitempty"`
+	StudentId     int64                  `protobuf:"varint,2,opt,name=student_id,json=studentId,proto3" json:"student_id,omitempty"`
+	DaysDue       int32                  `protobuf:"varint,3,opt,name=days_due,json=daysDue,proto3" json:"days_due,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IssueRequest) Reset() {
+	*x = IssueRequest{}
+	mi := &file_issue_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IssueRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IssueRequest) ProtoMessage() {}
+
+func (x *IssueRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_issue_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IssueRequest.ProtoReflect.Descriptor instead.
+func (*IssueRequest) Descriptor() ([]byte, []int) {
+	return file_issue_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *IssueRequest) GetBookId() int64 {
+	if x != nil {
+		return x.BookId
+	}
+	return 0
+}
+
+func (x *IssueRequest) GetStudentId() int64 {
+	if x != nil {
+		return x.StudentId
+	}
+	return 0
+}
+
+func (x *IssueRequest) GetDaysDue() int32 {
+	if x != nil {
+		return x.DaysDue
+	}
+	return 0
+}
+
+type IssueResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	IssueId       int64                  `protobuf:"varint,3,opt,name=issue_id,json=issueId,proto3" json:"issue_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IssueResponse) Reset() {
+	*x = IssueResponse{}
+	mi := &file_issue_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IssueResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IssueResponse) ProtoMessage() {}
+
+func (x *IssueResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_issue_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IssueResponse.ProtoReflect.Descriptor instead.
+func (*IssueResponse) Descriptor() ([]byte, []int) {
+	return file_issue_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *IssueResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *IssueResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *IssueResponse) GetIssueId() int64 {
+	if x != nil {
+		return x.IssueId
+	}
+	return 0
+}
+
+type CheckAvailabilityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BookId        int64                  `protobuf:"varint,1,opt,name=book_id,json=bookId,proto3" json:"book_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckAvailabilityRequest) Reset() {
+	*x = CheckAvailabilityRequest{}
+	mi := &file_issue_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckAvailabilityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckAvailabilityRequest) ProtoMessage() {}
+
+func (x *CheckAvailabilityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_issue_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckAvailabilityRequest.ProtoReflect.Descriptor instead.
+func (*CheckAvailabilityRequest) Descriptor() ([]byte, []int) {
+	return file_issue_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CheckAvailabilityRequest) GetBookId() int64 {
+	if x != nil {
+		return x.BookId
+	}
+	return 0
+}
+
+type CheckAvailabilityResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	AvailableCopies int32                  `protobuf:"varint,1,opt,name=available_copies,json=availableCopies,proto3" json:"available_copies,omitempty"`
+	TotalCopies     int32                  `protobuf:"varint,2,opt,name=total_copies,json=totalCopies,proto3" json:"total_copies,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CheckAvailabilityResponse) Reset() {
+	*x = CheckAvailabilityResponse{}
+	mi := &file_issue_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckAvailabilityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckAvailabilityResponse) ProtoMessage() {}
+
+func (x *CheckAvailabilityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_issue_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckAvailabilityResponse.ProtoReflect.Descriptor instead.
+func (*CheckAvailabilityResponse) Descriptor() ([]byte, []int) {
+	return file_issue_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CheckAvailabilityResponse) GetAvailableCopies() int32 {
+	if x != nil {
+		return x.AvailableCopies
+	}
+	return 0
+}
+
+func (x *CheckAvailabilityResponse) GetTotalCopies() int32 {
+	if x != nil {
+		return x.TotalCopies
+	}
+	return 0
+}
+
+type ReturnRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	IssueId       int64                  `protobuf:"varint,1,opt,name=issue_id,json=issueId,proto3" json:"issue_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReturnRequest) Reset() {
+	*x = ReturnRequest{}
+	mi := &file_issue_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReturnRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReturnRequest) ProtoMessage() {}
+
+func (x *ReturnRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_issue_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReturnRequest.ProtoReflect.Descriptor instead.
+func (*ReturnRequest) Descriptor() ([]byte, []int) {
+	return file_issue_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ReturnRequest) GetIssueId() int64 {
+	if x != nil {
+		return x.IssueId
+	}
+	return 0
+}
+
+type ReturnResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Fine          int32                  `protobuf:"varint,3,opt,name=fine,proto3" json:"fine,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReturnResponse) Reset() {
+	*x = ReturnResponse{}
+	mi := &file_issue_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReturnResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReturnResponse) ProtoMessage() {}
+
+func (x *ReturnResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_issue_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReturnResponse.ProtoReflect.Descriptor instead.
+func (*ReturnResponse) Descriptor() ([]byte, []int) {
+	return file_issue_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ReturnResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ReturnResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ReturnResponse) GetFine() int32 {
+	if x != nil {
+		return x.Fine
+	}
+	return 0
+}
+
+type ReportLostRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	IssueId       int64                  `protobuf:"varint,1,opt,name=issue_id,json=issueId,proto3" json:"issue_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportLostRequest) Reset() {
+	*x = ReportLostRequest{}
+	mi := &file_issue_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportLostRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportLostRequest) ProtoMessage() {}
+
+func (x *ReportLostRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_issue_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportLostRequest.ProtoReflect.Descriptor instead.
+func (*ReportLostRequest) Descriptor() ([]byte, []int) {
+	return file_issue_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ReportLostRequest) GetIssueId() int64 {
+	if x != nil {
+		return x.IssueId
+	}
+	return 0
+}
+
+type ReportLostResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Fine          int32                  `protobuf:"varint,3,opt,name=fine,proto3" json:"fine,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportLostResponse) Reset() {
+	*x = ReportLostResponse{}
+	mi := &file_issue_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportLostResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportLostResponse) ProtoMessage() {}
+
+func (x *ReportLostResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_issue_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportLostResponse.ProtoReflect.Descriptor instead.
+func (*ReportLostResponse) Descriptor() ([]byte, []int) {
+	return file_issue_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ReportLostResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ReportLostResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ReportLostResponse) GetFine() int32 {
+	if x != nil {
+		return x.Fine
+	}
+	return 0
+}
+
+type GetAllDebtsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAllDebtsRequest) Reset() {
+	*x = GetAllDebtsRequest{}
+	mi := &file_issue_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAllDebtsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAllDebtsRequest) ProtoMessage() {}
+
+func (x *GetAllDebtsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_issue_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAllDebtsRequest.ProtoReflect.Descriptor instead.
+func (*GetAllDebtsRequest) Descriptor() ([]byte, []int) {
+	return file_issue_proto_rawDescGZIP(), []int{8}
+}
+
+type GetAllDebtsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Debts         []*Debt                `protobuf:"bytes,1,rep,name=debts,proto3" json:"debts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAllDebtsResponse) Reset() {
+	*x = GetAllDebtsResponse{}
+	mi := &file_issue_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAllDebtsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAllDebtsResponse) ProtoMessage() {}
+
+func (x *GetAllDebtsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_issue_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAllDebtsResponse.ProtoReflect.Descriptor instead.
+func (*GetAllDebtsResponse) Descriptor() ([]byte, []int) {
+	return file_issue_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetAllDebtsResponse) GetDebts() []*Debt {
+	if x != nil {
+		return x.Debts
+	}
+	return nil
+}
+
+type ViewDebtorsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ViewDebtorsRequest) Reset() {
+	*x = ViewDebtorsRequest{}
+	mi := &file_issue_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ViewDebtorsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ViewDebtorsRequest) ProtoMessage() {}
+
+func (x *ViewDebtorsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_issue_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ViewDebtorsRequest.ProtoReflect.Descriptor instead.
+func (*ViewDebtorsRequest) Descriptor() ([]byte, []int) {
+	return file_issue_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ViewDebtorsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ViewDebtorsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Debts         []*Debt                `protobuf:"bytes,1,rep,name=debts,proto3" json:"debts,omitempty"`
+	FromCache     bool                   `protobuf:"varint,2,opt,name=from_cache,json=fromCache,proto3" json:"from_cache,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ViewDebtorsResponse) Reset() {
+	*x = ViewDebtorsResponse{}
+	mi := &file_issue_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ViewDebtorsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ViewDebtorsResponse) ProtoMessage() {}
+
+func (x *ViewDebtorsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_issue_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ViewDebtorsResponse.ProtoReflect.Descriptor instead.
+func (*ViewDebtorsResponse) Descriptor() ([]byte, []int) {
+	return file_issue_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ViewDebtorsResponse) GetDebts() []*Debt {
+	if x != nil {
+		return x.Debts
+	}
+	return nil
+}
+
+func (x *ViewDebtorsResponse) GetFromCache() bool {
+	if x != nil {
+		return x.FromCache
+	}
+	return false
+}
+
+type Debt struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	IssueId       int64                  `protobuf:"varint,1,opt,name=issue_id,json=issueId,proto3" json:"issue_id,omitempty"`
+	StudentId     int64                  `protobuf:"varint,2,opt,name=student_id,json=studentId,proto3" json:"student_id,omitempty"`
+	StudentName   string                 `protobuf:"bytes,3,opt,name=student_name,json=studentName,proto3" json:"student_name,omitempty"`
+	BookId        int64                  `protobuf:"varint,4,opt,name=book_id,json=bookId,proto3" json:"book_id,omitempty"`
+	BookTitle     string                 `protobuf:"bytes,5,opt,name=book_title,json=bookTitle,proto3" json:"book_title,omitempty"`
+	DueDate       *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=due_date,json=dueDate,proto3" json:"due_date,omitempty"`
+	OverdueDays   int32                  `protobuf:"varint,7,opt,name=overdue_days,json=overdueDays,proto3" json:"overdue_days,omitempty"`
+	Fine          int32                  `protobuf:"varint,8,opt,name=fine,proto3" json:"fine,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Debt) Reset() {
+	*x = Debt{}
+	mi := &file_issue_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Debt) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Debt) ProtoMessage() {}
+
+func (x *Debt) ProtoReflect() protoreflect.Message {
+	mi := &file_issue_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Debt.ProtoReflect.Descriptor instead.
+func (*Debt) Descriptor() ([]byte, []int) {
+	return file_issue_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Debt) GetIssueId() int64 {
+	if x != nil {
+		return x.IssueId
+	}
+	return 0
+}
+
+func (x *Debt) GetStudentId() int64 {
+	if x != nil {
+		return x.StudentId
+	}
+	return 0
+}
+
+func (x *Debt) GetStudentName() string {
+	if x != nil {
+		return x.StudentName
+	}
+	return ""
+}
+
+func (x *Debt) GetBookId() int64 {
+	if x != nil {
+		return x.BookId
+	}
+	return 0
+}
+
+func (x *Debt) GetBookTitle() string {
+	if x != nil {
+		return x.BookTitle
+	}
+	return ""
+}
+
+func (x *Debt) GetDueDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DueDate
+	}
+	return nil
+}
+
+func (x *Debt) GetOverdueDays() int32 {
+	if x != nil {
+		return x.OverdueDays
+	}
+	return 0
+}
+
+func (x *Debt) GetFine() int32 {
+	if x != nil {
+		return x.Fine
+	}
+	return 0
+}
+
+type AddBookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	TotalCopies   int32                  `protobuf:"varint,2,opt,name=total_copies,json=totalCopies,proto3" json:"total_copies,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddBookRequest) Reset() {
+	*x = AddBookRequest{}
+	mi := &file_issue_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddBookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddBookRequest) ProtoMessage() {}
+
+func (x *AddBookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_issue_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddBookRequest.ProtoReflect.Descriptor instead.
+func (*AddBookRequest) Descriptor() ([]byte, []int) {
+	return file_issue_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *AddBookRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *AddBookRequest) GetTotalCopies() int32 {
+	if x != nil {
+		return x.TotalCopies
+	}
+	return 0
+}
+
+type AddBookResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	BookId        int64                  `protobuf:"varint,3,opt,name=book_id,json=bookId,proto3" json:"book_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddBookResponse) Reset() {
+	*x = AddBookResponse{}
+	mi := &file_issue_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddBookResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddBookResponse) ProtoMessage() {}
+
+func (x *AddBookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_issue_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddBookResponse.ProtoReflect.Descriptor instead.
+func (*AddBookResponse) Descriptor() ([]byte, []int) {
+	return file_issue_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *AddBookResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *AddBookResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *AddBookResponse) GetBookId() int64 {
+	if x != nil {
+		return x.BookId
+	}
+	return 0
+}
+
+type GetBookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BookId        int64                  `protobuf:"varint,1,opt,name=book_id,json=bookId,proto3" json:"book_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBookRequest) Reset() {
+	*x = GetBookRequest{}
+	mi := &file_issue_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBookRequest) ProtoMessage() {}
+
+func (x *GetBookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_issue_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBookRequest.ProtoReflect.Descriptor instead.
+func (*GetBookRequest) Descriptor() ([]byte, []int) {
+	return file_issue_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetBookRequest) GetBookId() int64 {
+	if x != nil {
+		return x.BookId
+	}
+	return 0
+}
+
+type GetBookResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Success         bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message         string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	BookId          int64                  `protobuf:"varint,3,opt,name=book_id,json=bookId,proto3" json:"book_id,omitempty"`
+	Title           string                 `protobuf:"bytes,4,opt,name=title,proto3" json:"title,omitempty"`
+	TotalCopies     int32                  `protobuf:"varint,5,opt,name=total_copies,json=totalCopies,proto3" json:"total_copies,omitempty"`
+	AvailableCopies int32                  `protobuf:"varint,6,opt,name=available_copies,json=availableCopies,proto3" json:"available_copies,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetBookResponse) Reset() {
+	*x = GetBookResponse{}
+	mi := &file_issue_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBookResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBookResponse) ProtoMessage() {}
+
+func (x *GetBookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_issue_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBookResponse.ProtoReflect.Descriptor instead.
+func (*GetBookResponse) Descriptor() ([]byte, []int) {
+	return file_issue_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetBookResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *GetBookResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *GetBookResponse) GetBookId() int64 {
+	if x != nil {
+		return x.BookId
+	}
+	return 0
+}
+
+func (x *GetBookResponse) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *GetBookResponse) GetTotalCopies() int32 {
+	if x != nil {
+		return x.TotalCopies
+	}
+	return 0
+}
+
+func (x *GetBookResponse) GetAvailableCopies() int32 {
+	if x != nil {
+		return x.AvailableCopies
+	}
+	return 0
+}
+
+var File_issue_proto protoreflect.FileDescriptor
+
+const file_issue_proto_rawDesc = "" +
+	"\n" +
+	"\vissue.proto\x12\x05issue\x1a\x1fgoogle/protobuf/timestamp.proto\"E\n" +
+	"\fIssueRequest\x12\x0f\n" +
+	"\abook_id\x18\x01 \x01(\x03\x12\x12\n" +
+	"\n" +
+	"student_id\x18\x02 \x01(\x03\x12\x10\n" +
+	"\bdays_due\x18\x03 \x01(\x05\"C\n" +
+	"\rIssueResponse\x12\x0f\n" +
+	"\asuccess\x18\x01 \x01(\b\x12\x0f\n" +
+	"\amessage\x18\x02 \x01(\t\x12\x10\n" +
+	"\bissue_id\x18\x03 \x01(\x03\"+\n" +
+	"\x18CheckAvailabilityRequest\x12\x0f\n" +
+	"\abook_id\x18\x01 \x01(\x03\"K\n" +
+	"\x19CheckAvailabilityResponse\x12\x18\n" +
+	"\x10available_copies\x18\x01 \x01(\x05\x12\x14\n" +
+	"\ftotal_copies\x18\x02 \x01(\x05\"!\n" +
+	"\rReturnRequest\x12\x10\n" +
+	"\bissue_id\x18\x01 \x01(\x03\"@\n" +
+	"\x0eReturnResponse\x12\x0f\n" +
+	"\asuccess\x18\x01 \x01(\b\x12\x0f\n" +
+	"\amessage\x18\x02 \x01(\t\x12\f\n" +
+	"\x04fine\x18\x03 \x01(\x05\"%\n" +
+	"\x11ReportLostRequest\x12\x10\n" +
+	"\bissue_id\x18\x01 \x01(\x03\"D\n" +
+	"\x12ReportLostResponse\x12\x0f\n" +
+	"\asuccess\x18\x01 \x01(\b\x12\x0f\n" +
+	"\amessage\x18\x02 \x01(\t\x12\f\n" +
+	"\x04fine\x18\x03 \x01(\x05\"\x14\n" +
+	"\x12GetAllDebtsRequest\"1\n" +
+	"\x13GetAllDebtsResponse\x12\x1a\n" +
+	"\x05debts\x18\x01 \x03(\v2\v.issue.Debt\"#\n" +
+	"\x12ViewDebtorsRequest\x12\r\n" +
+	"\x05limit\x18\x01 \x01(\x05\"E\n" +
+	"\x13ViewDebtorsResponse\x12\x1a\n" +
+	"\x05debts\x18\x01 \x03(\v2\v.issue.Debt\x12\x12\n" +
+	"\n" +
+	"from_cache\x18\x02 \x01(\b\"\xb9\x01\n" +
+	"\x04Debt\x12\x10\n" +
+	"\bissue_id\x18\x01 \x01(\x03\x12\x12\n" +
+	"\n" +
+	"student_id\x18\x02 \x01(\x03\x12\x14\n" +
+	"\fstudent_name\x18\x03 \x01(\t\x12\x0f\n" +
+	"\abook_id\x18\x04 \x01(\x03\x12\x12\n" +
+	"\n" +
+	"book_title\x18\x05 \x01(\t\x12,\n" +
+	"\bdue_date\x18\x06 \x01(\v2\x1a.google.protobuf.Timestamp\x12\x14\n" +
+	"\foverdue_days\x18\a \x01(\x05\x12\f\n" +
+	"\x04fine\x18\b \x01(\x05\"I\n" +
+	"\x0eAddBookRequest\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12!\n" +
+	"\ftotal_copies\x18\x02 \x01(\x05R\vtotalCopies\"^\n" +
+	"\x0fAddBookResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x17\n" +
+	"\abook_id\x18\x03 \x01(\x03R\x06bookId\")\n" +
+	"\x0eGetBookRequest\x12\x17\n" +
+	"\abook_id\x18\x01 \x01(\x03R\x06bookId\"\xc2\x01\n" +
+	"\x0fGetBookResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x17\n" +
+	"\abook_id\x18\x03 \x01(\x03R\x06bookId\x12\x14\n" +
+	"\x05title\x18\x04 \x01(\tR\x05title\x12!\n" +
+	"\ftotal_copies\x18\x05 \x01(\x05R\vtotalCopies\x12)\n" +
+	"\x10available_copies\x18\x06 \x01(\x05R\x0favailableCopies2\xac\x04\n" +
+	"\fIssueService\x128\n" +
+	"\tIssueBook\x12\x13.issue.IssueRequest\x1a\x14.issue.IssueResponse\"\x00\x12X\n" +
+	"\x11CheckAvailability\x12\x1f.issue.CheckAvailabilityRequest\x1a .issue.CheckAvailabilityResponse\"\x00\x12;\n" +
+	"\n" +
+	"ReturnBook\x12\x14.issue.ReturnRequest\x1a\x15.issue.ReturnResponse\"\x00\x12C\n" +
+	"\n" +
+	"ReportLost\x12\x18.issue.ReportLostRequest\x1a\x19.issue.ReportLostResponse\"\x00\x12F\n" +
+	"\vGetAllDebts\x12\x19.issue.GetAllDebtsRequest\x1a\x1a.issue.GetAllDebtsResponse\"\x00\x12F\n" +
+	"\vViewDebtors\x12\x19.issue.ViewDebtorsRequest\x1a\x1a.issue.ViewDebtorsResponse\"\x00\x12:\n" +
+	"\aAddBook\x12\x15.issue.AddBookRequest\x1a\x16.issue.AddBookResponse\"\x00\x12:\n" +
+	"\aGetBook\x12\x15.issue.GetBookRequest\x1a\x16.issue.GetBookResponse\"\x00B'Z%LibAssistant_api/gen/go/issue;issuev1b\x06proto3"
+
+var (
+	file_issue_proto_rawDescOnce sync.Once
+	file_issue_proto_rawDescData []byte
+)
+
+func file_issue_proto_rawDescGZIP() []byte {
+	file_issue_proto_rawDescOnce.Do(func() {
+		file_issue_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_issue_proto_rawDesc), len(file_issue_proto_rawDesc)))
+	})
+	return file_issue_proto_rawDescData
+}
+
+var file_issue_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_issue_proto_goTypes = []any{
+	(*IssueRequest)(nil),              // 0: issue.IssueRequest
+	(*IssueResponse)(nil),             // 1: issue.IssueResponse
+	(*CheckAvailabilityRequest)(nil),  // 2: issue.CheckAvailabilityRequest
+	(*CheckAvailabilityResponse)(nil), // 3: issue.CheckAvailabilityResponse
+	(*ReturnRequest)(nil),             // 4: issue.ReturnRequest
+	(*ReturnResponse)(nil),            // 5: issue.ReturnResponse
+	(*ReportLostRequest)(nil),         // 6: issue.ReportLostRequest
+	(*ReportLostResponse)(nil),        // 7: issue.ReportLostResponse
+	(*GetAllDebtsRequest)(nil),        // 8: issue.GetAllDebtsRequest
+	(*GetAllDebtsResponse)(nil),       // 9: issue.GetAllDebtsResponse
+	(*ViewDebtorsRequest)(nil),        // 10: issue.ViewDebtorsRequest
+	(*ViewDebtorsResponse)(nil),       // 11: issue.ViewDebtorsResponse
+	(*Debt)(nil),                      // 12: issue.Debt
+	(*AddBookRequest)(nil),            // 13: issue.AddBookRequest
+	(*AddBookResponse)(nil),           // 14: issue.AddBookResponse
+	(*GetBookRequest)(nil),            // 15: issue.GetBookRequest
+	(*GetBookResponse)(nil),           // 16: issue.GetBookResponse
+	(*timestamppb.Timestamp)(nil),     // 17: google.protobuf.Timestamp
+}
+var file_issue_proto_depIdxs = []int32{
+	12, // 0: issue.GetAllDebtsResponse.debts:type_name -> issue.Debt
+	12, // 1: issue.ViewDebtorsResponse.debts:type_name -> issue.Debt
+	17, // 2: issue.Debt.due_date:type_name -> google.protobuf.Timestamp
+	0,  // 3: issue.IssueService.IssueBook:input_type -> issue.IssueRequest
+	2,  // 4: issue.IssueService.CheckAvailability:input_type -> issue.CheckAvailabilityRequest
+	4,  // 5: issue.IssueService.ReturnBook:input_type -> issue.ReturnRequest
+	6,  // 6: issue.IssueService.ReportLost:input_type -> issue.ReportLostRequest
+	8,  // 7: issue.IssueService.GetAllDebts:input_type -> issue.GetAllDebtsRequest
+	10, // 8: issue.IssueService.ViewDebtors:input_type -> issue.ViewDebtorsRequest
+	13, // 9: issue.IssueService.AddBook:input_type -> issue.AddBookRequest
+	15, // 10: issue.IssueService.GetBook:input_type -> issue.GetBookRequest
+	1,  // 11: issue.IssueService.IssueBook:output_type -> issue.IssueResponse
+	3,  // 12: issue.IssueService.CheckAvailability:output_type -> issue.CheckAvailabilityResponse
+	5,  // 13: issue.IssueService.ReturnBook:output_type -> issue.ReturnResponse
+	7,  // 14: issue.IssueService.ReportLost:output_type -> issue.ReportLostResponse
+	9,  // 15: issue.IssueService.GetAllDebts:output_type -> issue.GetAllDebtsResponse
+	11, // 16: issue.IssueService.ViewDebtors:output_type -> issue.ViewDebtorsResponse
+	14, // 17: issue.IssueService.AddBook:output_type -> issue.AddBookResponse
+	16, // 18: issue.IssueService.GetBook:output_type -> issue.GetBookResponse
+	11, // [11:19] is the sub-list for method output_type
+	3,  // [3:11] is the sub-list for method input_type
+	3,  // [3:3] is the sub-list for extension type_name
+	3,  // [3:3] is the sub-list for extension extendee
+	0,  // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_issue_proto_init() }
+func file_issue_proto_init() {
+	if File_issue_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_issue_proto_rawDesc), len(file_issue_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   17,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_issue_proto_goTypes,
+		DependencyIndexes: file_issue_proto_depIdxs,
+		MessageInfos:      file_issue_proto_msgTypes,
+	}.Build()
+	File_issue_proto = out.File
+	file_issue_proto_goTypes = nil
+	file_issue_proto_depIdxs = nil
+}
@@ -0,0 +1,387 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v6.33.1
+// source: issue.proto
+
+package issuev1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	IssueService_IssueBook_FullMethodName         = "/issue.IssueService/IssueBook"
+	IssueService_CheckAvailability_FullMethodName = "/issue.IssueService/CheckAvailability"
+	IssueService_ReturnBook_FullMethodName        = "/issue.IssueService/ReturnBook"
+	IssueService_ReportLost_FullMethodName        = "/issue.IssueService/ReportLost"
+	IssueService_GetAllDebts_FullMethodName       = "/issue.IssueService/GetAllDebts"
+	IssueService_ViewDebtors_FullMethodName       = "/issue.IssueService/ViewDebtors"
+	IssueService_AddBook_FullMethodName           = "/issue.IssueService/AddBook"
+	IssueService_GetBook_FullMethodName           = "/issue.IssueService/GetBook"
+)
+
+// IssueServiceClient is the client API for IssueService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type IssueServiceClient interface {
+	IssueBook(ctx context.Context, in *IssueRequest, opts ...grpc.CallOption) (*IssueResponse, error)
+	CheckAvailability(ctx context.Context, in *CheckAvailabilityRequest, opts ...grpc.CallOption) (*CheckAvailabilityResponse, error)
+	ReturnBook(ctx context.Context, in *ReturnRequest, opts ...grpc.CallOption) (*ReturnResponse, error)
+	ReportLost(ctx context.Context, in *ReportLostRequest, opts ...grpc.CallOption) (*ReportLostResponse, error)
+	GetAllDebts(ctx context.Context, in *GetAllDebtsRequest, opts ...grpc.CallOption) (*GetAllDebtsResponse, error)
+	ViewDebtors(ctx context.Context, in *ViewDebtorsRequest, opts ...grpc.CallOption) (*ViewDebtorsResponse, error)
+	AddBook(ctx context.Context, in *AddBookRequest, opts ...grpc.CallOption) (*AddBookResponse, error)
+	GetBook(ctx context.Context, in *GetBookRequest, opts ...grpc.CallOption) (*GetBookResponse, error)
+}
+
+type issueServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewIssueServiceClient(cc grpc.ClientConnInterface) IssueServiceClient {
+	return &issueServiceClient{cc}
+}
+
+func (c *issueServiceClient) IssueBook(ctx context.Context, in *IssueRequest, opts ...grpc.CallOption) (*IssueResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(IssueResponse)
+	err := c.cc.Invoke(ctx, IssueService_IssueBook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *issueServiceClient) CheckAvailability(ctx context.Context, in *CheckAvailabilityRequest, opts ...grpc.CallOption) (*CheckAvailabilityResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckAvailabilityResponse)
+	err := c.cc.Invoke(ctx, IssueService_CheckAvailability_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *issueServiceClient) ReturnBook(ctx context.Context, in *ReturnRequest, opts ...grpc.CallOption) (*ReturnResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReturnResponse)
+	err := c.cc.Invoke(ctx, IssueService_ReturnBook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *issueServiceClient) ReportLost(ctx context.Context, in *ReportLostRequest, opts ...grpc.CallOption) (*ReportLostResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReportLostResponse)
+	err := c.cc.Invoke(ctx, IssueService_ReportLost_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *issueServiceClient) GetAllDebts(ctx context.Context, in *GetAllDebtsRequest, opts ...grpc.CallOption) (*GetAllDebtsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAllDebtsResponse)
+	err := c.cc.Invoke(ctx, IssueService_GetAllDebts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *issueServiceClient) ViewDebtors(ctx context.Context, in *ViewDebtorsRequest, opts ...grpc.CallOption) (*ViewDebtorsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ViewDebtorsResponse)
+	err := c.cc.Invoke(ctx, IssueService_ViewDebtors_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *issueServiceClient) AddBook(ctx context.Context, in *AddBookRequest, opts ...grpc.CallOption) (*AddBookResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddBookResponse)
+	err := c.cc.Invoke(ctx, IssueService_AddBook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *issueServiceClient) GetBook(ctx context.Context, in *GetBookRequest, opts ...grpc.CallOption) (*GetBookResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetBookResponse)
+	err := c.cc.Invoke(ctx, IssueService_GetBook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// IssueServiceServer is the server API for IssueService service.
+// All implementations must embed UnimplementedIssueServiceServer
+// for forward compatibility.
+type IssueServiceServer interface {
+	IssueBook(context.Context, *IssueRequest) (*IssueResponse, error)
+	CheckAvailability(context.Context, *CheckAvailabilityRequest) (*CheckAvailabilityResponse, error)
+	ReturnBook(context.Context, *ReturnRequest) (*ReturnResponse, error)
+	ReportLost(context.Context, *ReportLostRequest) (*ReportLostResponse, error)
+	GetAllDebts(context.Context, *GetAllDebtsRequest) (*GetAllDebtsResponse, error)
+	ViewDebtors(context.Context, *ViewDebtorsRequest) (*ViewDebtorsResponse, error)
+	AddBook(context.Context, *AddBookRequest) (*AddBookResponse, error)
+	GetBook(context.Context, *GetBookRequest) (*GetBookResponse, error)
+	mustEmbedUnimplementedIssueServiceServer()
+}
+
+// UnimplementedIssueServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedIssueServiceServer struct{}
+
+func (UnimplementedIssueServiceServer) IssueBook(context.Context, *IssueRequest) (*IssueResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IssueBook not implemented")
+}
+func (UnimplementedIssueServiceServer) CheckAvailability(context.Context, *CheckAvailabilityRequest) (*CheckAvailabilityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckAvailability not implemented")
+}
+func (UnimplementedIssueServiceServer) ReturnBook(context.Context, *ReturnRequest) (*ReturnResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReturnBook not implemented")
+}
+func (UnimplementedIssueServiceServer) ReportLost(context.Context, *ReportLostRequest) (*ReportLostResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportLost not implemented")
+}
+func (UnimplementedIssueServiceServer) GetAllDebts(context.Context, *GetAllDebtsRequest) (*GetAllDebtsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAllDebts not implemented")
+}
+func (UnimplementedIssueServiceServer) ViewDebtors(context.Context, *ViewDebtorsRequest) (*ViewDebtorsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ViewDebtors not implemented")
+}
+func (UnimplementedIssueServiceServer) AddBook(context.Context, *AddBookRequest) (*AddBookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddBook not implemented")
+}
+func (UnimplementedIssueServiceServer) GetBook(context.Context, *GetBookRequest) (*GetBookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBook not implemented")
+}
+func (UnimplementedIssueServiceServer) mustEmbedUnimplementedIssueServiceServer() {}
+func (UnimplementedIssueServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeIssueServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to IssueServiceServer will
+// result in compilation errors.
+type UnsafeIssueServiceServer interface {
+	mustEmbedUnimplementedIssueServiceServer()
+}
+
+func RegisterIssueServiceServer(s grpc.ServiceRegistrar, srv IssueServiceServer) {
+	// If the following call pancis, it indicates UnimplementedIssueServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&IssueService_ServiceDesc, srv)
+}
+
+func _IssueService_IssueBook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IssueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IssueServiceServer).IssueBook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IssueService_IssueBook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IssueServiceServer).IssueBook(ctx, req.(*IssueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IssueService_CheckAvailability_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckAvailabilityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IssueServiceServer).CheckAvailability(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IssueService_CheckAvailability_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IssueServiceServer).CheckAvailability(ctx, req.(*CheckAvailabilityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IssueService_ReturnBook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReturnRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IssueServiceServer).ReturnBook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IssueService_ReturnBook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IssueServiceServer).ReturnBook(ctx, req.(*ReturnRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IssueService_ReportLost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportLostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IssueServiceServer).ReportLost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IssueService_ReportLost_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IssueServiceServer).ReportLost(ctx, req.(*ReportLostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IssueService_GetAllDebts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAllDebtsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IssueServiceServer).GetAllDebts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IssueService_GetAllDebts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IssueServiceServer).GetAllDebts(ctx, req.(*GetAllDebtsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IssueService_ViewDebtors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ViewDebtorsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IssueServiceServer).ViewDebtors(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IssueService_ViewDebtors_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IssueServiceServer).ViewDebtors(ctx, req.(*ViewDebtorsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IssueService_AddBook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddBookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IssueServiceServer).AddBook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IssueService_AddBook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IssueServiceServer).AddBook(ctx, req.(*AddBookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IssueService_GetBook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IssueServiceServer).GetBook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IssueService_GetBook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IssueServiceServer).GetBook(ctx, req.(*GetBookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// IssueService_ServiceDesc is the grpc.ServiceDesc for IssueService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var IssueService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "issue.IssueService",
+	HandlerType: (*IssueServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "IssueBook",
+			Handler:    _IssueService_IssueBook_Handler,
+		},
+		{
+			MethodName: "CheckAvailability",
+			Handler:    _IssueService_CheckAvailability_Handler,
+		},
+		{
+			MethodName: "ReturnBook",
+			Handler:    _IssueService_ReturnBook_Handler,
+		},
+		{
+			MethodName: "ReportLost",
+			Handler:    _IssueService_ReportLost_Handler,
+		},
+		{
+			MethodName: "GetAllDebts",
+			Handler:    _IssueService_GetAllDebts_Handler,
+		},
+		{
+			MethodName: "ViewDebtors",
+			Handler:    _IssueService_ViewDebtors_Handler,
+		},
+		{
+			MethodName: "AddBook",
+			Handler:    _IssueService_AddBook_Handler,
+		},
+		{
+			MethodName: "GetBook",
+			Handler:    _IssueService_GetBook_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "issue.proto",
+}
@@ -0,0 +1,130 @@
+package grpcclient
+
+import (
+	"LibAssistant_api/internal/config"
+	"LibAssistant_lib/certs"
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"sync/atomic"
+	"time"
+
+	grpclog "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
+	grpcretry "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/retry"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// Conn is a connection to a backend service shared by the gRPC clients: TLS from
+// certificates that are reloaded when they change, payload logging, and a retry
+// policy that can change at runtime.
+type Conn struct {
+	*grpc.ClientConn
+
+	certs *certs.Reloader
+
+	timeout atomic.Int64
+	retries atomic.Uint32
+}
+
+// Dial connects to cfg.Address. retryOpts are the retry defaults of every call;
+// opts are appended to the default dial options, e.g. for metrics interceptors.
+func Dial(ctx context.Context, log *slog.Logger, cfg config.Client, retryOpts []grpcretry.CallOption, opts ...grpc.DialOption) (*Conn, error) {
+	const op = "grpcclient.Dial"
+
+	creds := insecure.NewCredentials()
+
+	var reloader *certs.Reloader
+	if !cfg.Insecure {
+		var err error
+
+		reloader, err = certs.New(log, cfg.TLS.CertFile, cfg.TLS.KeyFile, cfg.TLS.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		creds = credentials.NewTLS(reloader.ClientConfig(serverName(cfg)))
+
+		go reloader.Watch(cfg.TLS.ReloadInterval)
+	}
+
+	logOpts := []grpclog.Option{
+		grpclog.WithLogOnEvents(grpclog.PayloadReceived, grpclog.PayloadSent),
+	}
+
+	opts = append([]grpc.DialOption{
+		grpc.WithTransportCredentials(creds),
+		grpc.WithChainUnaryInterceptor(
+			grpclog.UnaryClientInterceptor(interceptorLogger(log), logOpts...),
+			grpcretry.UnaryClientInterceptor(retryOpts...),
+		),
+	}, opts...)
+
+	cc, err := grpc.DialContext(ctx, cfg.Address, opts...)
+	if err != nil {
+		if reloader != nil {
+			reloader.Stop()
+		}
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	c := &Conn{
+		ClientConn: cc,
+		certs:      reloader,
+	}
+
+	c.SetRetryPolicy(cfg.Timeout, cfg.RetriesCount)
+
+	return c, nil
+}
+
+// SetRetryPolicy changes the per-attempt timeout and retry count of calls started
+// from now on; safe for concurrent use.
+func (c *Conn) SetRetryPolicy(timeout time.Duration, retries int) {
+	c.timeout.Store(int64(timeout))
+	c.retries.Store(uint32(retries))
+}
+
+// Timeout returns the current per-attempt timeout.
+func (c *Conn) Timeout() time.Duration {
+	return time.Duration(c.timeout.Load())
+}
+
+// CallOptions applies the current retry policy to a call, followed by opts.
+func (c *Conn) CallOptions(opts ...grpc.CallOption) []grpc.CallOption {
+	return append([]grpc.CallOption{
+		grpcretry.WithMax(uint(c.retries.Load())),
+		grpcretry.WithPerRetryTimeout(time.Duration(c.timeout.Load())),
+	}, opts...)
+}
+
+// Close closes the connection and stops watching certificates.
+func (c *Conn) Close() error {
+	if c.certs != nil {
+		c.certs.Stop()
+	}
+
+	return c.ClientConn.Close()
+}
+
+func serverName(cfg config.Client) string {
+	if cfg.TLS.ServerName != "" {
+		return cfg.TLS.ServerName
+	}
+
+	host, _, err := net.SplitHostPort(cfg.Address)
+	if err != nil {
+		return cfg.Address
+	}
+
+	return host
+}
+
+func interceptorLogger(l *slog.Logger) grpclog.Logger {
+	return grpclog.LoggerFunc(func(ctx context.Context, level grpclog.Level, msg string, fields ...any) {
+		l.Log(ctx, slog.Level(level), msg, fields...)
+	})
+}
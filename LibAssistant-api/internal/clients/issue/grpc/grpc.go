@@ -0,0 +1,313 @@
+package issuegrpc
+
+import (
+	issuev1 "LibAssistant_api/gen/go/issue"
+	"LibAssistant_api/internal/clients/grpcclient"
+	"LibAssistant_api/internal/config"
+	"LibAssistant_api/internal/lib/api/problem"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	grpcretry "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/retry"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Client calls the issue service. SetRetryPolicy and Close come from the connection.
+type Client struct {
+	*grpcclient.Conn
+
+	api issuev1.IssueServiceClient
+	log *slog.Logger
+}
+
+// Book is a catalog entry as stored by the issue service.
+type Book struct {
+	ID              int64
+	Title           string
+	TotalCopies     int32
+	AvailableCopies int32
+}
+
+// Debt is an open loan with the overdue days and fine computed at request time.
+type Debt struct {
+	LoanID      int64
+	StudentID   int64
+	StudentName string
+	BookID      int64
+	BookTitle   string
+	DueDate     time.Time
+	OverdueDays int32
+	Fine        int32
+}
+
+var (
+	ErrInternal        = errors.New("internal error")
+	ErrInvalidArgument = errors.New("invalid argument")
+	ErrUnavailable     = errors.New("issue service is unavailable")
+	ErrBookNotFound    = errors.New("book not found")
+	ErrStudentNotFound = errors.New("student not found")
+	ErrNoCopies        = errors.New("no available copies")
+	ErrLoanNotFound    = errors.New("loan not found")
+	ErrAlreadyReturned = errors.New("loan already returned")
+	ErrAlreadyLost     = errors.New("loan already reported lost")
+)
+
+func init() {
+	problem.Register(ErrBookNotFound, http.StatusNotFound, problem.CodeBookNotFound, "Book not found")
+	problem.Register(ErrStudentNotFound, http.StatusNotFound, problem.CodeStudentNotFound, "Student not found")
+	problem.Register(ErrLoanNotFound, http.StatusNotFound, problem.CodeLoanNotFound, "Loan not found")
+	problem.Register(ErrNoCopies, http.StatusConflict, problem.CodeNoCopies, "No copies of this book are available")
+	problem.Register(ErrAlreadyReturned, http.StatusConflict, problem.CodeAlreadyReturned, "The book has already been returned")
+	problem.Register(ErrAlreadyLost, http.StatusConflict, problem.CodeAlreadyLost, "The book has already been reported lost")
+	problem.Register(ErrInvalidArgument, http.StatusBadRequest, problem.CodeValidationFailed, "The issue service rejected the request")
+	problem.Register(ErrUnavailable, http.StatusServiceUnavailable, problem.CodeUnavailable, "Service is temporarily unavailable, try again later")
+}
+
+// The issue service reports business failures as success=false with one of these
+// messages instead of a gRPC status.
+var failures = map[string]error{
+	"Book not found":        ErrBookNotFound,
+	"Student not found":     ErrStudentNotFound,
+	"No available copies":   ErrNoCopies,
+	"Issue not found":       ErrLoanNotFound,
+	"Already returned":      ErrAlreadyReturned,
+	"Already reported lost": ErrAlreadyLost,
+}
+
+func failure(message string) error {
+	if err, ok := failures[message]; ok {
+		return err
+	}
+
+	return fmt.Errorf("%w: %s", ErrInternal, message)
+}
+
+func statusError(err error) error {
+	st, ok := status.FromError(err)
+	if !ok {
+		return ErrInternal
+	}
+
+	switch st.Code() {
+	case codes.InvalidArgument:
+		return fmt.Errorf("%w: %s", ErrInvalidArgument, st.Message())
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return ErrUnavailable
+	default:
+		return ErrInternal
+	}
+}
+
+// New dials the issue service. opts are appended to the default dial options, e.g. for metrics interceptors.
+func New(ctx context.Context, log *slog.Logger, cfg config.Client, opts ...grpc.DialOption) (*Client, error) {
+	const op = "issuegrpc.New"
+
+	conn, err := grpcclient.Dial(ctx, log, cfg, nil, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &Client{
+		Conn: conn,
+		api:  issuev1.NewIssueServiceClient(conn),
+		log:  log,
+	}, nil
+}
+
+// callOptions only retries a call that may already have reached the service, e.g.
+// one that timed out, if it is a read. Loans are not idempotent: retrying IssueBook
+// after a lost response could issue a second copy.
+//
+// The retry interceptor retries every DeadlineExceeded while a per-attempt timeout
+// is set, whatever the codes, so writes drop it and are bounded by writeContext.
+func (c *Client) callOptions(read bool) []grpc.CallOption {
+	if read {
+		return c.CallOptions(grpcretry.WithCodes(codes.Unavailable, codes.Aborted, codes.DeadlineExceeded))
+	}
+
+	return c.CallOptions(grpcretry.WithCodes(codes.Unavailable), grpcretry.WithPerRetryTimeout(0))
+}
+
+// writeContext bounds a write, retries included, by the per-attempt timeout.
+func (c *Client) writeContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if timeout := c.Timeout(); timeout > 0 {
+		return context.WithTimeout(ctx, timeout)
+	}
+
+	return ctx, func() {}
+}
+
+// IssueBook lends a copy of a book to a student for daysDue days, or the service
+// default of 14 if daysDue is 0, and returns the loan id.
+func (c *Client) IssueBook(ctx context.Context, bookID, studentID int64, daysDue int32) (int64, error) {
+	ctx, cancel := c.writeContext(ctx)
+	defer cancel()
+
+	resp, err := c.api.IssueBook(ctx, &issuev1.IssueRequest{
+		BookId:    bookID,
+		StudentId: studentID,
+		DaysDue:   daysDue,
+	}, c.callOptions(false)...)
+	if err != nil {
+		return 0, statusError(err)
+	}
+
+	if !resp.GetSuccess() {
+		return 0, failure(resp.GetMessage())
+	}
+
+	return resp.GetIssueId(), nil
+}
+
+// ReturnBook closes a loan and returns the fine due for it.
+func (c *Client) ReturnBook(ctx context.Context, loanID int64) (int32, error) {
+	ctx, cancel := c.writeContext(ctx)
+	defer cancel()
+
+	resp, err := c.api.ReturnBook(ctx, &issuev1.ReturnRequest{
+		IssueId: loanID,
+	}, c.callOptions(false)...)
+	if err != nil {
+		return 0, statusError(err)
+	}
+
+	if !resp.GetSuccess() {
+		return 0, failure(resp.GetMessage())
+	}
+
+	return resp.GetFine(), nil
+}
+
+// ReportLost marks the book of a loan as lost and returns the fine due for it.
+func (c *Client) ReportLost(ctx context.Context, loanID int64) (int32, error) {
+	ctx, cancel := c.writeContext(ctx)
+	defer cancel()
+
+	resp, err := c.api.ReportLost(ctx, &issuev1.ReportLostRequest{
+		IssueId: loanID,
+	}, c.callOptions(false)...)
+	if err != nil {
+		return 0, statusError(err)
+	}
+
+	if !resp.GetSuccess() {
+		return 0, failure(resp.GetMessage())
+	}
+
+	return resp.GetFine(), nil
+}
+
+// CheckAvailability returns the available and total copies of a book. The service
+// answers 0 of 0 for unknown books, which is reported as ErrBookNotFound.
+func (c *Client) CheckAvailability(ctx context.Context, bookID int64) (available int32, total int32, err error) {
+	resp, err := c.api.CheckAvailability(ctx, &issuev1.CheckAvailabilityRequest{
+		BookId: bookID,
+	}, c.callOptions(true)...)
+	if err != nil {
+		return 0, 0, statusError(err)
+	}
+
+	if resp.GetTotalCopies() == 0 {
+		return 0, 0, ErrBookNotFound
+	}
+
+	return resp.GetAvailableCopies(), resp.GetTotalCopies(), nil
+}
+
+// AddBook adds a title to the catalog with all of its copies available and returns its id.
+func (c *Client) AddBook(ctx context.Context, title string, totalCopies int32) (int64, error) {
+	ctx, cancel := c.writeContext(ctx)
+	defer cancel()
+
+	resp, err := c.api.AddBook(ctx, &issuev1.AddBookRequest{
+		Title:       title,
+		TotalCopies: totalCopies,
+	}, c.callOptions(false)...)
+	if err != nil {
+		return 0, statusError(err)
+	}
+
+	if !resp.GetSuccess() {
+		return 0, failure(resp.GetMessage())
+	}
+
+	return resp.GetBookId(), nil
+}
+
+func (c *Client) GetBook(ctx context.Context, bookID int64) (Book, error) {
+	resp, err := c.api.GetBook(ctx, &issuev1.GetBookRequest{
+		BookId: bookID,
+	}, c.callOptions(true)...)
+	if err != nil {
+		// Unlike the older rpcs, GetBook reports a missing book as a NotFound status.
+		if status.Code(err) == codes.NotFound {
+			return Book{}, ErrBookNotFound
+		}
+
+		return Book{}, statusError(err)
+	}
+
+	if !resp.GetSuccess() {
+		return Book{}, failure(resp.GetMessage())
+	}
+
+	return Book{
+		ID:              resp.GetBookId(),
+		Title:           resp.GetTitle(),
+		TotalCopies:     resp.GetTotalCopies(),
+		AvailableCopies: resp.GetAvailableCopies(),
+	}, nil
+}
+
+// GetAllDebts returns every open loan, computed from the service database.
+func (c *Client) GetAllDebts(ctx context.Context) ([]Debt, error) {
+	resp, err := c.api.GetAllDebts(ctx, &issuev1.GetAllDebtsRequest{}, c.callOptions(true)...)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return debts(resp.GetDebts()), nil
+}
+
+// ViewDebtors returns open loans ordered by fine, at most limit of them if limit is
+// positive. The service may answer from a short-lived cache, reported by fromCache.
+func (c *Client) ViewDebtors(ctx context.Context, limit int32) (list []Debt, fromCache bool, err error) {
+	resp, err := c.api.ViewDebtors(ctx, &issuev1.ViewDebtorsRequest{
+		Limit: limit,
+	}, c.callOptions(true)...)
+	if err != nil {
+		return nil, false, statusError(err)
+	}
+
+	return debts(resp.GetDebts()), resp.GetFromCache(), nil
+}
+
+func debts(in []*issuev1.Debt) []Debt {
+	out := make([]Debt, 0, len(in))
+
+	for _, d := range in {
+		debt := Debt{
+			LoanID:      d.GetIssueId(),
+			StudentID:   d.GetStudentId(),
+			StudentName: d.GetStudentName(),
+			BookID:      d.GetBookId(),
+			BookTitle:   d.GetBookTitle(),
+			OverdueDays: d.GetOverdueDays(),
+			Fine:        d.GetFine(),
+		}
+
+		if d.GetDueDate() != nil {
+			debt.DueDate = d.GetDueDate().AsTime()
+		}
+
+		out = append(out, debt)
+	}
+
+	return out
+}
@@ -0,0 +1,209 @@
+package issuegrpc
+
+import (
+	issuev1 "LibAssistant_api/gen/go/issue"
+	"LibAssistant_api/internal/clients/issue/grpc/issuegrpctest"
+	"LibAssistant_api/internal/lib/api/problem"
+	"context"
+	"log/slog"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeService answers every rpc with err, or else with message as a failure, and
+// counts the calls that reach it. With hang set IssueBook waits for the caller to
+// give up.
+type fakeService struct {
+	issuev1.UnimplementedIssueServiceServer
+
+	err     error
+	message string
+	hang    bool
+	calls   atomic.Int32
+}
+
+func (f *fakeService) IssueBook(ctx context.Context, _ *issuev1.IssueRequest) (*issuev1.IssueResponse, error) {
+	f.calls.Add(1)
+
+	if f.hang {
+		<-ctx.Done()
+
+		return nil, ctx.Err()
+	}
+
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	return &issuev1.IssueResponse{Message: f.message}, nil
+}
+
+func (f *fakeService) ReturnBook(context.Context, *issuev1.ReturnRequest) (*issuev1.ReturnResponse, error) {
+	f.calls.Add(1)
+
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	return &issuev1.ReturnResponse{Message: f.message}, nil
+}
+
+func (f *fakeService) GetBook(context.Context, *issuev1.GetBookRequest) (*issuev1.GetBookResponse, error) {
+	f.calls.Add(1)
+
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	return &issuev1.GetBookResponse{Message: f.message}, nil
+}
+
+func (f *fakeService) CheckAvailability(context.Context, *issuev1.CheckAvailabilityRequest) (*issuev1.CheckAvailabilityResponse, error) {
+	f.calls.Add(1)
+
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	return &issuev1.CheckAvailabilityResponse{}, nil
+}
+
+func newClient(t *testing.T, srv *fakeService) *Client {
+	t.Helper()
+
+	cfg, dialer := issuegrpctest.Serve(t, srv)
+
+	c, err := New(context.Background(), slog.New(slog.DiscardHandler), cfg, dialer)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = c.Close() })
+
+	return c
+}
+
+func TestFailures(t *testing.T) {
+	tests := []struct {
+		message string
+		want    error
+		status  int
+	}{
+		{"Book not found", ErrBookNotFound, http.StatusNotFound},
+		{"Student not found", ErrStudentNotFound, http.StatusNotFound},
+		{"No available copies", ErrNoCopies, http.StatusConflict},
+		{"Issue not found", ErrLoanNotFound, http.StatusNotFound},
+		{"Already returned", ErrAlreadyReturned, http.StatusConflict},
+		{"Already reported lost", ErrAlreadyLost, http.StatusConflict},
+		{"Something new", ErrInternal, http.StatusInternalServerError},
+	}
+
+	require.Len(t, failures, len(tests)-1, "every failure message is tested")
+
+	for _, tt := range tests {
+		t.Run(tt.message, func(t *testing.T) {
+			c := newClient(t, &fakeService{message: tt.message})
+
+			_, err := c.IssueBook(context.Background(), 1, 2, 0)
+			require.ErrorIs(t, err, tt.want)
+			assert.Equal(t, tt.status, problem.Translate(err).Problem.Status)
+		})
+	}
+}
+
+func TestStatusErrors(t *testing.T) {
+	ctx := context.Background()
+
+	issue := func(c *Client) error { _, err := c.IssueBook(ctx, 1, 2, 0); return err }
+	getBook := func(c *Client) error { _, err := c.GetBook(ctx, 1); return err }
+	availability := func(c *Client) error { _, _, err := c.CheckAvailability(ctx, 1); return err }
+
+	tests := []struct {
+		name   string
+		call   func(*Client) error
+		err    error
+		want   error
+		status int
+	}{
+		{"unavailable", issue, status.Error(codes.Unavailable, "connection refused"), ErrUnavailable, http.StatusServiceUnavailable},
+		{"deadline exceeded", issue, status.Error(codes.DeadlineExceeded, "deadline exceeded"), ErrUnavailable, http.StatusServiceUnavailable},
+		{"invalid argument", issue, status.Error(codes.InvalidArgument, "days_due too large"), ErrInvalidArgument, http.StatusBadRequest},
+		{"internal", issue, status.Error(codes.Internal, "boom"), ErrInternal, http.StatusInternalServerError},
+		{"get book not found", getBook, status.Error(codes.NotFound, "book not found"), ErrBookNotFound, http.StatusNotFound},
+		{"get book unavailable", getBook, status.Error(codes.Unavailable, "connection refused"), ErrUnavailable, http.StatusServiceUnavailable},
+		{"availability of an unknown book", availability, nil, ErrBookNotFound, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call(newClient(t, &fakeService{err: tt.err}))
+			require.ErrorIs(t, err, tt.want)
+			assert.Equal(t, tt.status, problem.Translate(err).Problem.Status)
+		})
+	}
+}
+
+// TestRetries checks writes are only retried when they cannot have reached the service.
+func TestRetries(t *testing.T) {
+	ctx := context.Background()
+
+	// issuegrpctest allows 3 attempts.
+	tests := []struct {
+		name  string
+		call  func(*Client) error
+		srv   *fakeService
+		calls int32
+	}{
+		{
+			name:  "issue book is not retried after a timeout",
+			call:  func(c *Client) error { _, err := c.IssueBook(ctx, 1, 2, 0); return err },
+			srv:   &fakeService{err: status.Error(codes.DeadlineExceeded, "deadline exceeded")},
+			calls: 1,
+		},
+		{
+			name:  "issue book is not retried when aborted",
+			call:  func(c *Client) error { _, err := c.IssueBook(ctx, 1, 2, 0); return err },
+			srv:   &fakeService{err: status.Error(codes.Aborted, "aborted")},
+			calls: 1,
+		},
+		{
+			name:  "return book is not retried after a timeout",
+			call:  func(c *Client) error { _, err := c.ReturnBook(ctx, 1); return err },
+			srv:   &fakeService{err: status.Error(codes.DeadlineExceeded, "deadline exceeded")},
+			calls: 1,
+		},
+		{
+			name:  "issue book is retried while unavailable",
+			call:  func(c *Client) error { _, err := c.IssueBook(ctx, 1, 2, 0); return err },
+			srv:   &fakeService{err: status.Error(codes.Unavailable, "connection refused")},
+			calls: 3,
+		},
+		{
+			name: "issue book is bounded by the timeout",
+			call: func(c *Client) error {
+				c.SetRetryPolicy(50*time.Millisecond, 3)
+				_, err := c.IssueBook(ctx, 1, 2, 0)
+				return err
+			},
+			srv:   &fakeService{hang: true},
+			calls: 1,
+		},
+		{
+			name:  "get book is retried after a timeout",
+			call:  func(c *Client) error { _, err := c.GetBook(ctx, 1); return err },
+			srv:   &fakeService{err: status.Error(codes.DeadlineExceeded, "deadline exceeded")},
+			calls: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Error(t, tt.call(newClient(t, tt.srv)))
+			assert.Equal(t, tt.calls, tt.srv.calls.Load())
+		})
+	}
+}
@@ -0,0 +1,42 @@
+// Package issuegrpctest serves a fake issue service in memory, for tests of the
+// issue client and of the handlers that use it.
+package issuegrpctest
+
+import (
+	issuev1 "LibAssistant_api/gen/go/issue"
+	"LibAssistant_api/internal/config"
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// Serve serves srv until the test ends. Pass the returned config and dial option
+// to issuegrpc.New to connect to it; each call is tried at most 3 times.
+func Serve(t testing.TB, srv issuev1.IssueServiceServer) (config.Client, grpc.DialOption) {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+
+	s := grpc.NewServer()
+	issuev1.RegisterIssueServiceServer(s, srv)
+
+	go func() { _ = s.Serve(lis) }()
+	t.Cleanup(s.Stop)
+
+	cfg := config.Client{
+		Address:      "bufnet",
+		Timeout:      time.Second,
+		RetriesCount: 3,
+		Insecure:     true,
+	}
+
+	dialer := grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+		return lis.DialContext(ctx)
+	})
+
+	return cfg, dialer
+}
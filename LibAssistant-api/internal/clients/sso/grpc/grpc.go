@@ -1,22 +1,29 @@
 package ssogrpc
 
 import (
+	"LibAssistant_api/internal/clients/grpcclient"
+	"LibAssistant_api/internal/config"
+	"LibAssistant_api/internal/lib/api/problem"
 	"context"
 	"errors"
 	"fmt"
 	"log/slog"
-	"time"
+	"net/http"
+	"sort"
+	"strings"
 
 	ssov1 "github.com/MKode312/protos/gen/go/LibAssistant/sso"
-	grpclog "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
 	grpcretry "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/retry"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/status"
 )
 
+// Client calls the SSO Auth API. SetRetryPolicy and Close come from the connection.
 type Client struct {
+	*grpcclient.Conn
+
 	api ssov1.AuthClient
 	log *slog.Logger
 }
@@ -27,83 +34,143 @@ var (
 	ErrWrongAdminSecret   = errors.New("the provided admin secret key is wrong")
 	ErrUserExists         = errors.New("user already exists")
 	ErrUserNotFound       = errors.New("user not found")
+	ErrAccountDisabled    = errors.New("account is disabled")
+	ErrUnavailable        = errors.New("sso is unavailable")
 )
 
-func New(ctx context.Context, log *slog.Logger, addr string, timeout time.Duration, retriesCount int) (*Client, error) {
+func init() {
+	problem.Register(ErrInvalidCredentials, http.StatusUnauthorized, problem.CodeInvalidCredentials, "Invalid email or password")
+	problem.Register(ErrUserExists, http.StatusConflict, problem.CodeUserExists, "A user with this email already exists")
+	problem.Register(ErrWrongAdminSecret, http.StatusForbidden, problem.CodeWrongAdminSecret, "The admin secret is wrong")
+	problem.Register(ErrUserNotFound, http.StatusNotFound, problem.CodeUserNotFound, "User not found")
+	problem.Register(ErrAccountDisabled, http.StatusForbidden, problem.CodeAccountDisabled, "The account is disabled")
+	problem.Register(ErrUnavailable, http.StatusServiceUnavailable, problem.CodeUnavailable, "Service is temporarily unavailable, try again later")
+}
+
+// statusError maps an SSO error. PermissionDenied means something different for
+// each rpc, so the caller passes the error it stands for.
+func statusError(err error, permissionDenied error) error {
+	st, ok := status.FromError(err)
+	if !ok {
+		return fmt.Errorf("%w: %w", ErrInternal, err)
+	}
+
+	switch st.Code() {
+	case codes.InvalidArgument:
+		return invalidArgument(st)
+	case codes.AlreadyExists:
+		return ErrUserExists
+	case codes.NotFound:
+		return ErrUserNotFound
+	case codes.PermissionDenied:
+		return permissionDenied
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return ErrUnavailable
+	default:
+		return fmt.Errorf("%w: %s", ErrInternal, st.Message())
+	}
+}
+
+// FieldError is returned when SSO rejects a request with per-field violations,
+// keyed by request field name. It wraps ErrInvalidCredentials.
+type FieldError struct {
+	Fields map[string]string
+}
+
+// Names returns the invalid fields in a stable, sorted order.
+func (e *FieldError) Names() []string {
+	names := make([]string, 0, len(e.Fields))
+	for name := range e.Fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// FieldViolations lets problem.Translate report the fields without knowing this type.
+func (e *FieldError) FieldViolations() map[string]string {
+	return e.Fields
+}
+
+func (e *FieldError) Error() string {
+	names := e.Names()
+
+	msgs := make([]string, 0, len(names))
+	for _, name := range names {
+		msgs = append(msgs, name+": "+e.Fields[name])
+	}
+
+	return "invalid request: " + strings.Join(msgs, "; ")
+}
+
+func (e *FieldError) Unwrap() error {
+	return ErrInvalidCredentials
+}
+
+// invalidArgument decodes the errdetails.BadRequest of an InvalidArgument status.
+// Without field violations it is a plain ErrInvalidCredentials.
+func invalidArgument(st *status.Status) error {
+	fields := make(map[string]string)
+
+	for _, detail := range st.Details() {
+		br, ok := detail.(*errdetails.BadRequest)
+		if !ok {
+			continue
+		}
+
+		for _, v := range br.GetFieldViolations() {
+			fields[v.GetField()] = v.GetDescription()
+		}
+	}
+
+	if len(fields) == 0 {
+		return ErrInvalidCredentials
+	}
+
+	return &FieldError{Fields: fields}
+}
+
+// New dials SSO. opts are appended to the default dial options, e.g. for metrics interceptors.
+func New(ctx context.Context, log *slog.Logger, cfg config.Client, opts ...grpc.DialOption) (*Client, error) {
 	const op = "grpc.New"
 
 	retryOpts := []grpcretry.CallOption{
 		grpcretry.WithCodes(codes.NotFound, codes.Aborted, codes.DeadlineExceeded),
-		grpcretry.WithMax(uint(retriesCount)),
-		grpcretry.WithPerRetryTimeout(timeout),
-	}
-
-	logOpts := []grpclog.Option{
-		grpclog.WithLogOnEvents(grpclog.PayloadReceived, grpclog.PayloadSent),
 	}
 
-	cc, err := grpc.DialContext(ctx, addr,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-		grpc.WithChainUnaryInterceptor(
-			grpclog.UnaryClientInterceptor(interceptorLogger(log), logOpts...),
-			grpcretry.UnaryClientInterceptor(retryOpts...),
-		))
+	conn, err := grpcclient.Dial(ctx, log, cfg, retryOpts, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
 	return &Client{
-		api: ssov1.NewAuthClient(cc),
-		log: log,
+		Conn: conn,
+		api:  ssov1.NewAuthClient(conn),
+		log:  log,
 	}, nil
 }
 
 func (c *Client) RegisterNewUser(ctx context.Context, email string, password string) (int64, error) {
-
 	resp, err := c.api.Register(ctx, &ssov1.RegisterRequest{
 		Email:    email,
 		Password: password,
-	})
+	}, c.CallOptions()...)
 	if err != nil {
-		st, ok := status.FromError(err)
-		if ok {
-			if st.Code() == codes.InvalidArgument {
-				return 0, ErrInvalidCredentials
-			}
-
-			if st.Code() == codes.AlreadyExists {
-				return 0, ErrUserExists
-			}
-		}
-		return 0, ErrInternal
+		return 0, statusError(err, ErrInvalidCredentials)
 	}
 
 	return resp.GetUserId(), nil
 }
 
 func (c *Client) RegisterNewAdmin(ctx context.Context, email, passowrd, admin_secret string) (int64, error) {
-
 	resp, err := c.api.RegisterAsAdmin(ctx, &ssov1.RegisterAsAdminRequest{
 		Email:       email,
 		Password:    passowrd,
 		AdminSecret: admin_secret,
-	})
+	}, c.CallOptions()...)
 	if err != nil {
-		st, ok := status.FromError(err)
-		if ok {
-			if st.Code() == codes.PermissionDenied {
-				return 0, ErrWrongAdminSecret
-			}
-
-			if st.Code() == codes.AlreadyExists {
-				return 0, ErrUserExists
-			}
-
-			if st.Code() == codes.InvalidArgument {
-				return 0, ErrInvalidCredentials
-			}
-		}
-		return 0, ErrInternal
+		return 0, statusError(err, ErrWrongAdminSecret)
 	}
 
 	return resp.GetUserId(), nil
@@ -113,45 +180,21 @@ func (c *Client) Login(ctx context.Context, email, password string) (string, err
 	resp, err := c.api.Login(ctx, &ssov1.LoginRequest{
 		Email:    email,
 		Password: password,
-	})
+	}, c.CallOptions()...)
 	if err != nil {
-		st, ok := status.FromError(err)
-		if ok {
-			if st.Code() == codes.InvalidArgument {
-				return "", ErrInvalidCredentials
-			}
-
-			return "", ErrInternal
-		}
+		return "", statusError(err, ErrAccountDisabled)
 	}
 
 	return resp.GetToken(), nil
 }
 
 func (c *Client) IsAdmin(ctx context.Context, userID int64) (bool, error) {
-
 	resp, err := c.api.IsAdmin(ctx, &ssov1.IsAdminRequest{
 		UserId: userID,
-	})
+	}, c.CallOptions()...)
 	if err != nil {
-		st, ok := status.FromError(err)
-		if ok {
-			if st.Code() == codes.NotFound {
-				return false, ErrUserNotFound
-			}
-
-			if st.Code() == codes.InvalidArgument {
-				return false, ErrInvalidCredentials
-			}
-		}
-		return false, ErrInternal
+		return false, statusError(err, ErrInternal)
 	}
 
 	return resp.GetIsAdmin(), nil
 }
-
-func interceptorLogger(l *slog.Logger) grpclog.Logger {
-	return grpclog.LoggerFunc(func(ctx context.Context, level grpclog.Level, msg string, fields ...any) {
-		l.Log(ctx, slog.Level(level), msg, fields...)
-	})
-}
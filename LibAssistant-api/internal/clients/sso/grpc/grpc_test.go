@@ -0,0 +1,89 @@
+package ssogrpc
+
+import (
+	"LibAssistant_api/internal/clients/grpcclient"
+	"LibAssistant_api/internal/lib/api/problem"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"testing"
+
+	ssov1 "github.com/MKode312/protos/gen/go/LibAssistant/sso"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeAuth returns err from every rpc.
+type fakeAuth struct {
+	ssov1.AuthClient
+
+	err error
+}
+
+func (f *fakeAuth) Login(context.Context, *ssov1.LoginRequest, ...grpc.CallOption) (*ssov1.LoginResponse, error) {
+	return nil, f.err
+}
+
+func (f *fakeAuth) Register(context.Context, *ssov1.RegisterRequest, ...grpc.CallOption) (*ssov1.RegisterResponse, error) {
+	return nil, f.err
+}
+
+func (f *fakeAuth) RegisterAsAdmin(context.Context, *ssov1.RegisterAsAdminRequest, ...grpc.CallOption) (*ssov1.RegisterAsAdminResponse, error) {
+	return nil, f.err
+}
+
+func (f *fakeAuth) IsAdmin(context.Context, *ssov1.IsAdminRequest, ...grpc.CallOption) (*ssov1.IsAdminResponse, error) {
+	return nil, f.err
+}
+
+func newClient(err error) *Client {
+	return &Client{
+		Conn: &grpcclient.Conn{},
+		api:  &fakeAuth{err: err},
+		log:  slog.New(slog.DiscardHandler),
+	}
+}
+
+func TestErrors(t *testing.T) {
+	ctx := context.Background()
+
+	login := func(c *Client) error { _, err := c.Login(ctx, "a@b.c", "secret"); return err }
+	register := func(c *Client) error { _, err := c.RegisterNewUser(ctx, "a@b.c", "secret"); return err }
+	registerAdmin := func(c *Client) error { _, err := c.RegisterNewAdmin(ctx, "a@b.c", "secret", "admin"); return err }
+	isAdmin := func(c *Client) error { _, err := c.IsAdmin(ctx, 1); return err }
+
+	tests := []struct {
+		name   string
+		call   func(*Client) error
+		err    error
+		want   error
+		status int
+	}{
+		{"login invalid", login, status.Error(codes.InvalidArgument, "invalid email or password"), ErrInvalidCredentials, http.StatusUnauthorized},
+		{"login disabled", login, status.Error(codes.PermissionDenied, "account is disabled"), ErrAccountDisabled, http.StatusForbidden},
+		{"login unavailable", login, status.Error(codes.Unavailable, "connection refused"), ErrUnavailable, http.StatusServiceUnavailable},
+		{"login deadline", login, status.Error(codes.DeadlineExceeded, "deadline exceeded"), ErrUnavailable, http.StatusServiceUnavailable},
+		{"login not a status", login, errors.New("boom"), ErrInternal, http.StatusInternalServerError},
+		{"login internal", login, status.Error(codes.Internal, "internal error"), ErrInternal, http.StatusInternalServerError},
+		{"register exists", register, status.Error(codes.AlreadyExists, "user already exists"), ErrUserExists, http.StatusConflict},
+		{"register denied", register, status.Error(codes.PermissionDenied, "invalid email or password"), ErrInvalidCredentials, http.StatusUnauthorized},
+		{"register unavailable", register, status.Error(codes.Unavailable, "connection refused"), ErrUnavailable, http.StatusServiceUnavailable},
+		{"admin wrong secret", registerAdmin, status.Error(codes.PermissionDenied, "wrong admin secret key"), ErrWrongAdminSecret, http.StatusForbidden},
+		{"admin exists", registerAdmin, status.Error(codes.AlreadyExists, "user already exists"), ErrUserExists, http.StatusConflict},
+		{"is admin not found", isAdmin, status.Error(codes.NotFound, "user not found"), ErrUserNotFound, http.StatusNotFound},
+		{"is admin unavailable", isAdmin, status.Error(codes.Unavailable, "connection refused"), ErrUnavailable, http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call(newClient(tt.err))
+			require.Error(t, err)
+			assert.ErrorIs(t, err, tt.want)
+			assert.Equal(t, tt.status, problem.Translate(err).Problem.Status)
+		})
+	}
+}
@@ -1,24 +1,134 @@
 package config
 
 import (
+	"LibAssistant_lib/tracing"
+	"errors"
 	"flag"
+	"fmt"
+	"net/netip"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/ilyakaznacheev/cleanenv"
 )
 
 type Config struct {
-	Env        string        `yaml:"env" env-default:"local"`
-	HTTPServer HTTPServer    `yaml:"http_server"`
-	Clients    ClientsConfig `yaml:"clients"`
-	AppSecret  string        `yaml:"app_secret" env-required:"true"`
+	Env        string         `yaml:"env" env-default:"local"`
+	LogLevel   string         `yaml:"log_level" env:"LOG_LEVEL"`
+	Reload     Reload         `yaml:"reload"`
+	HTTPServer HTTPServer     `yaml:"http_server"`
+	Clients    ClientsConfig  `yaml:"clients"`
+	AppSecret  string         `yaml:"app_secret" env:"APP_SECRET"`
+	Authz      Authz          `yaml:"authz"`
+	Metrics    Metrics        `yaml:"metrics"`
+	Tracing    tracing.Config `yaml:"tracing"`
+
+	// Path is the file the config was loaded from, kept for reloads.
+	Path string `yaml:"-"`
 }
 
+// Reload controls how often the config file is checked for changes; 0 disables
+// polling, SIGHUP always triggers a reload.
+type Reload struct {
+	Interval time.Duration `yaml:"interval" env-default:"5s"`
+}
+
+// Authz.ClaimsMaxAge is how long the roles in an access token are trusted before
+// they are checked against SSO again.
+type Authz struct {
+	ClaimsMaxAge time.Duration `yaml:"claims_max_age" env-default:"5m"`
+}
+
+// Metrics sets the address Prometheus metrics are served on; empty disables them.
+type Metrics struct {
+	Address string `yaml:"address" env:"METRICS_ADDRESS" env-default:"0.0.0.0:9091"`
+}
+
+// HTTPServer.ShutdownDelay is how long /readyz reports failure before draining starts;
+// ShutdownTimeout bounds the drain of in-flight requests.
 type HTTPServer struct {
-	Address     string        `yaml:"address" env-required:"true"`
-	Timeout     time.Duration `yaml:"timeout" env-default:"4s"`
-	IdleTimeout time.Duration `yaml:"idle_timeout" env-default:"60s"`
+	Address         string        `yaml:"address"`
+	Timeout         time.Duration `yaml:"timeout" env-default:"4s"`
+	IdleTimeout     time.Duration `yaml:"idle_timeout" env-default:"60s"`
+	ShutdownDelay   time.Duration `yaml:"shutdown_delay" env-default:"5s"`
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" env-default:"15s"`
+	RateLimit       RateLimit     `yaml:"rate_limit"`
+	CORS            CORS          `yaml:"cors"`
+}
+
+// CORS lists the browser origins, e.g. "https://library.example.com", that may call
+// the API with cookies; an empty list allows none. MaxAge is how long browsers may
+// cache a preflight response.
+type CORS struct {
+	AllowedOrigins []string      `yaml:"allowed_origins" env:"CORS_ALLOWED_ORIGINS" env-separator:","`
+	MaxAge         time.Duration `yaml:"max_age" env-default:"10m"`
+}
+
+// RateLimit throttles the authentication routes with token buckets. Policies are
+// keyed by route name ("login", "register", "register_as_admin"); a route missing
+// from the file keeps its default policy from DefaultRateLimitPolicies.
+//
+// TrustedProxies are the addresses or CIDR ranges, e.g. "10.0.0.0/8", of reverse
+// proxies in front of the gateway. Requests from them are limited by the client
+// address they put in X-Forwarded-For; with an empty list the peer address is the
+// client, so a proxy would put all its clients in one bucket.
+type RateLimit struct {
+	Enabled        bool                       `yaml:"enabled" env:"RATE_LIMIT_ENABLED" env-default:"true"`
+	Policies       map[string]RateLimitPolicy `yaml:"policies"`
+	TrustedProxies []string                   `yaml:"trusted_proxies" env:"RATE_LIMIT_TRUSTED_PROXIES" env-separator:","`
+}
+
+// ParseProxy parses a TrustedProxies entry; a single address is a one-address range.
+func ParseProxy(s string) (netip.Prefix, error) {
+	if strings.Contains(s, "/") {
+		p, err := netip.ParsePrefix(s)
+		if err != nil {
+			return netip.Prefix{}, err
+		}
+
+		return p.Masked(), nil
+	}
+
+	addr, err := netip.ParseAddr(s)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+
+	addr = addr.Unmap()
+
+	return netip.PrefixFrom(addr, addr.BitLen()), nil
+}
+
+// RateLimitPolicy limits a route per client IP and per email in the request body.
+type RateLimitPolicy struct {
+	IP    Limit `yaml:"ip"`
+	Email Limit `yaml:"email"`
+}
+
+// Limit allows bursts of up to Requests, refilled evenly over Per; 0 Requests is no limit.
+type Limit struct {
+	Requests int           `yaml:"requests"`
+	Per      time.Duration `yaml:"per"`
+}
+
+// DefaultRateLimitPolicies are strict for admin registration, whose secret could
+// otherwise be guessed by trying it over and over.
+func DefaultRateLimitPolicies() map[string]RateLimitPolicy {
+	return map[string]RateLimitPolicy{
+		"login": {
+			IP:    Limit{Requests: 20, Per: time.Minute},
+			Email: Limit{Requests: 5, Per: time.Minute},
+		},
+		"register": {
+			IP:    Limit{Requests: 10, Per: time.Hour},
+			Email: Limit{Requests: 3, Per: time.Hour},
+		},
+		"register_as_admin": {
+			IP:    Limit{Requests: 5, Per: time.Hour},
+			Email: Limit{Requests: 3, Per: time.Hour},
+		},
+	}
 }
 
 type Client struct {
@@ -26,34 +136,83 @@ type Client struct {
 	Timeout      time.Duration `yaml:"timeout"`
 	RetriesCount int           `yaml:"retries_count"`
 	Insecure     bool          `yaml:"insecure"`
+	TLS          ClientTLS     `yaml:"tls"`
+}
+
+// ClientTLS is used when Insecure is false. An empty CAFile means the system roots;
+// CertFile and KeyFile enable mutual TLS. Files are polled every ReloadInterval.
+type ClientTLS struct {
+	CAFile         string        `yaml:"ca_file"`
+	CertFile       string        `yaml:"cert_file"`
+	KeyFile        string        `yaml:"key_file"`
+	ServerName     string        `yaml:"server_name"`
+	ReloadInterval time.Duration `yaml:"reload_interval" env-default:"30s"`
 }
 
 type ClientsConfig struct {
-	SSO Client `yaml:"sso"`
+	SSO   Client `yaml:"sso"`
+	Issue Client `yaml:"issue"`
 }
 
 func MustLoad() *Config {
+	cfg, err := LoadFromFlags()
+	if err != nil {
+		panic(err)
+	}
+
+	return cfg
+}
+
+// LoadFromFlags loads the config named by the -config flag or CONFIG_PATH.
+func LoadFromFlags() (*Config, error) {
 	configPath := fetchConfigPath()
 
 	if configPath == "" {
-		panic("config path is empty")
+		return nil, errors.New("config path is empty")
 	}
 
-	return MustLoadByPath(configPath)
+	return Load(configPath)
 }
 
 func MustLoadByPath(configPath string) *Config {
+	cfg, err := Load(configPath)
+	if err != nil {
+		panic(err)
+	}
+
+	return cfg
+}
+
+// Load reads the config file, environment overrides and secret files, then validates
+// the result. It does not panic, so it is also used for reloads.
+func Load(configPath string) (*Config, error) {
 	if _, err := os.Stat(configPath); os.IsNotExist(err) {
-		panic("config file does not exist: " + configPath)
+		return nil, fmt.Errorf("config file does not exist: %s", configPath)
 	}
 
 	var cfg Config
 
 	if err := cleanenv.ReadConfig(configPath, &cfg); err != nil {
-		panic("cannot read config: " + err.Error())
+		return nil, fmt.Errorf("cannot read config: %w", err)
+	}
+
+	policies := DefaultRateLimitPolicies()
+	for route, policy := range cfg.HTTPServer.RateLimit.Policies {
+		policies[route] = policy
 	}
+	cfg.HTTPServer.RateLimit.Policies = policies
+
+	if err := cfg.readSecretFiles(); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
+	cfg.Path = configPath
 
-	return &cfg
+	return &cfg, nil
 }
 
 func fetchConfigPath() string {
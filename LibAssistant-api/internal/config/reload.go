@@ -0,0 +1,30 @@
+package config
+
+import (
+	"LibAssistant_lib/reload"
+	"log/slog"
+)
+
+// reloadable lists the fields, by dotted yaml path, that can change at runtime.
+var reloadable = map[string]bool{
+	"log_level":                   true,
+	"http_server.rate_limit":      true,
+	"http_server.cors":            true,
+	"clients.sso.timeout":         true,
+	"clients.sso.retries_count":   true,
+	"clients.issue.timeout":       true,
+	"clients.issue.retries_count": true,
+}
+
+// CheckReload returns reload.ErrUnsafeChange naming every field of next that differs
+// from c and cannot be applied without a restart, e.g. addresses or TLS files.
+func (c *Config) CheckReload(next *Config) error {
+	return reload.Check(*c, *next, reloadable)
+}
+
+type Watcher = reload.Watcher[Config]
+
+// NewWatcher starts from cfg; apply is called with every accepted config.
+func NewWatcher(log *slog.Logger, cfg *Config, apply func(*Config)) *Watcher {
+	return reload.NewWatcher(log, cfg.Path, cfg, Load, reloadable, apply)
+}
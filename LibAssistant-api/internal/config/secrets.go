@@ -0,0 +1,90 @@
+package config
+
+import (
+	"LibAssistant_lib/secrets"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+)
+
+// secrets lists the credentials in c; see secrets.Secret for how they are read.
+func (c *Config) secrets() []secrets.Secret {
+	return []secrets.Secret{
+		{Env: "APP_SECRET", Value: &c.AppSecret},
+	}
+}
+
+func (c *Config) readSecretFiles() error {
+	return secrets.ReadFiles(c.secrets())
+}
+
+// Validate reports every required field that is missing or invalid, not just the first.
+func (c *Config) Validate() error {
+	var errs []error
+
+	if c.AppSecret == "" {
+		errs = append(errs, errors.New("app_secret is required (APP_SECRET or APP_SECRET_FILE)"))
+	}
+
+	if c.HTTPServer.Address == "" {
+		errs = append(errs, errors.New("http_server.address is required"))
+	}
+
+	if c.Clients.SSO.Address == "" {
+		errs = append(errs, errors.New("clients.sso.address is required"))
+	}
+
+	for route, policy := range c.HTTPServer.RateLimit.Policies {
+		for key, limit := range map[string]Limit{"ip": policy.IP, "email": policy.Email} {
+			if limit.Requests < 0 || (limit.Requests > 0 && limit.Per <= 0) {
+				errs = append(errs, fmt.Errorf("http_server.rate_limit.policies.%s.%s: requests must not be negative and per must be positive", route, key))
+			}
+		}
+	}
+
+	for _, proxy := range c.HTTPServer.RateLimit.TrustedProxies {
+		if _, err := ParseProxy(proxy); err != nil {
+			errs = append(errs, fmt.Errorf("http_server.rate_limit.trusted_proxies: %w", err))
+		}
+	}
+
+	for _, origin := range c.HTTPServer.CORS.AllowedOrigins {
+		if err := validateOrigin(origin); err != nil {
+			errs = append(errs, fmt.Errorf("http_server.cors.allowed_origins: %w", err))
+		}
+	}
+
+	if c.Clients.Issue.Address == "" {
+		errs = append(errs, errors.New("clients.issue.address is required"))
+	}
+
+	if c.LogLevel != "" {
+		var level slog.Level
+		if err := level.UnmarshalText([]byte(c.LogLevel)); err != nil {
+			errs = append(errs, fmt.Errorf("invalid log_level: %w", err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Redacted returns a copy of c with secrets masked, safe to print or log.
+func (c *Config) Redacted() Config {
+	r := *c
+	secrets.Mask(r.secrets())
+
+	return r
+}
+
+// validateOrigin accepts an origin as browsers send it: scheme and host, nothing
+// else. A wildcard is refused, since credentials may not be shared with any origin.
+func validateOrigin(origin string) error {
+	u, err := url.Parse(origin)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" ||
+		u.User != nil || (u.Path != "" && u.Path != "/") || u.RawQuery != "" || u.Fragment != "" {
+		return fmt.Errorf("%q is not an origin like https://example.com", origin)
+	}
+
+	return nil
+}
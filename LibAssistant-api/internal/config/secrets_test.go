@@ -0,0 +1,99 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func validConfig() *Config {
+	cfg := &Config{AppSecret: "s3cret"}
+	cfg.HTTPServer.Address = "0.0.0.0:8082"
+	cfg.Clients.SSO.Address = "sso:44043"
+	cfg.Clients.Issue.Address = "issue:50051"
+
+	return cfg
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(c *Config)
+		errs   []string
+	}{
+		{
+			name:   "valid",
+			change: func(*Config) {},
+		},
+		{
+			name: "every problem is reported",
+			change: func(c *Config) {
+				c.AppSecret = ""
+				c.HTTPServer.Address = ""
+				c.LogLevel = "loud"
+			},
+			errs: []string{"app_secret is required", "http_server.address is required", "invalid log_level"},
+		},
+		{
+			name: "rate limit without a period",
+			change: func(c *Config) {
+				c.HTTPServer.RateLimit.Policies = map[string]RateLimitPolicy{"login": {IP: Limit{Requests: 5}}}
+			},
+			errs: []string{"http_server.rate_limit.policies.login.ip"},
+		},
+		{
+			name: "zero requests disables a limit",
+			change: func(c *Config) {
+				c.HTTPServer.RateLimit.Policies = map[string]RateLimitPolicy{"login": {IP: Limit{Requests: 5, Per: time.Minute}}}
+			},
+		},
+		{
+			name: "trusted proxies",
+			change: func(c *Config) {
+				c.HTTPServer.RateLimit.TrustedProxies = []string{"10.0.0.0/8", "192.168.1.10", "::1", "proxy.local", "10.0.0.0/33"}
+			},
+			errs: []string{`"proxy.local"`, `"10.0.0.0/33"`},
+		},
+		{
+			name: "origins",
+			change: func(c *Config) {
+				c.HTTPServer.CORS.AllowedOrigins = []string{"https://app.example.com", "http://localhost:3000/", "*", "https://example.com/path"}
+			},
+			errs: []string{`"*" is not an origin`, `"https://example.com/path" is not an origin`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := validConfig()
+			tt.change(cfg)
+
+			err := cfg.Validate()
+			if len(tt.errs) == 0 {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			require.Error(t, err)
+			for _, e := range tt.errs {
+				assert.ErrorContains(t, err, e)
+			}
+		})
+	}
+}
+
+func TestRedacted(t *testing.T) {
+	cfg := validConfig()
+
+	r := cfg.Redacted()
+
+	assert.Equal(t, "[REDACTED]", r.AppSecret)
+	assert.Equal(t, "s3cret", cfg.AppSecret, "the config itself is left alone")
+	assert.Equal(t, cfg.HTTPServer.Address, r.HTTPServer.Address)
+
+	cfg.AppSecret = ""
+	assert.Empty(t, cfg.Redacted().AppSecret, "a missing secret stays visible")
+}
@@ -2,20 +2,23 @@ package isAdmin
 
 import (
 	ssogrpc "LibAssistant_api/internal/clients/sso/grpc"
+	MWAuthz "LibAssistant_api/internal/http-server/middleware/authz"
+	"LibAssistant_api/internal/lib/api/problem"
+	"LibAssistant_api/internal/lib/api/request"
 	resp "LibAssistant_api/internal/lib/api/response"
-	"LibAssistant_api/internal/lib/logger/sl"
 	"context"
-	"errors"
 	"log/slog"
 	"net/http"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
-	"github.com/go-playground/validator/v10"
 )
 
+// Request is the body of the legacy POST /isAdmin. UserID defaults to the caller;
+// only users with the users:read permission may ask about others.
 type Request struct {
-	UserID int64 `json:"userID" validate:"required"`
+	UserID int64 `json:"userID" validate:"omitempty,gt=0"`
 }
 
 type Response struct {
@@ -23,120 +26,90 @@ type Response struct {
 	IsAdmin bool `json:"isAdmin"`
 }
 
-func New(ctx context.Context, log *slog.Logger, ssoClient *ssogrpc.Client) http.HandlerFunc {
+// New answers for the user in the {id} path parameter, whose route requires the
+// users:read permission, or for the caller on routes without one. It answers 200
+// either way; whether the user is an admin is in the body.
+func New(ctx context.Context, log *slog.Logger, ssoClient *ssogrpc.Client, authz *MWAuthz.Authorizer) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "handlers.Auth.IsAdmin.New"
 
-		log = log.With(
+		log := log.With(
 			slog.String("op", op),
 			slog.String("request_id", middleware.GetReqID(r.Context())),
 		)
 
-		var req Request
-
-		if err := render.DecodeJSON(r.Body, &req); err != nil {
-			log.Error("failed to decode request body", sl.Err(err))
-
-			w.WriteHeader(http.StatusInternalServerError)
+		if chi.URLParam(r, "id") == "" {
+			claims, ok := authz.Authorize(w, r)
+			if !ok {
+				return
+			}
 
-			render.JSON(w, r, resp.Error("Unknown error"))
+			check(w, r, log, ssoClient, claims.UserID)
 
 			return
 		}
 
-		log.Info("request body decoded", slog.Any("request", req))
-
-		if err := validator.New().Struct(req); err != nil {
-			validationErr := err.(validator.ValidationErrors)
-
-			log.Error("invalid request", sl.Err(err))
-
-			w.WriteHeader(http.StatusBadRequest)
-
-			render.JSON(w, r, resp.Error("Invalid request"))
-			render.JSON(w, r, resp.ValidationError(validationErr))
+		userID, err := request.PathID(r, "id")
+		if err != nil {
+			problem.Render(w, r, log, err)
 
 			return
 		}
 
-		userID := req.UserID
-
-		isAdmin, err := ssoClient.IsAdmin(ctx, userID)
-		if err != nil {
-			if errors.Is(err, ssogrpc.ErrInvalidCredentials) {
-				log.Error("invalid credentials")
-
-				w.WriteHeader(http.StatusBadRequest)
-
-				render.JSON(w, r, resp.Error("Invalid email or password"))
+		check(w, r, log, ssoClient, userID)
+	}
+}
 
-				return
-			}
+// NewLegacy serves POST /isAdmin, which names the user in the body.
+func NewLegacy(ctx context.Context, log *slog.Logger, ssoClient *ssogrpc.Client, authz *MWAuthz.Authorizer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.Auth.IsAdmin.NewLegacy"
 
-			if errors.Is(err, ssogrpc.ErrUserNotFound) {
-				log.Error("user not found")
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
 
-				w.WriteHeader(http.StatusUnprocessableEntity)
+		var req Request
 
-				render.JSON(w, r, resp.Error("Not found"))
+		if err := request.Decode(r, &req); err != nil {
+			problem.Render(w, r, log, err)
 
-				return
-			}
+			return
+		}
 
-			if errors.Is(err, ssogrpc.ErrInternal) {
-				log.Error("internal error")
+		claims, ok := authz.Authorize(w, r)
+		if !ok {
+			return
+		}
 
-				w.WriteHeader(http.StatusInternalServerError)
+		userID := req.UserID
+		if userID == 0 {
+			userID = claims.UserID
+		}
 
-				render.JSON(w, r, resp.Error("Unknown internal error"))
+		if userID != claims.UserID && !MWAuthz.Can(claims, MWAuthz.PermUsersRead) {
+			authz.Deny(w, r, claims, "permission "+string(MWAuthz.PermUsersRead))
 
-				return
-			}
+			return
+		}
 
-			log.Error("failed to check if user is admin", sl.Err(err))
+		check(w, r, log, ssoClient, userID)
+	}
+}
 
-			w.WriteHeader(http.StatusInternalServerError)
+func check(w http.ResponseWriter, r *http.Request, log *slog.Logger, ssoClient *ssogrpc.Client, userID int64) {
+	isAdmin, err := ssoClient.IsAdmin(r.Context(), userID)
+	if err != nil {
+		problem.Render(w, r, log, err)
 
-			render.JSON(w, r, resp.Error("Unknown error"))
+		return
+	}
 
-			return
-		}
+	log.InfoContext(r.Context(), "checked if user is admin", slog.Int64("user_id", userID), slog.Bool("is_admin", isAdmin))
 
-		if isAdmin {
-			http.SetCookie(w, &http.Cookie{
-				Name:     "isAdmin",
-				Value:    "true",
-				HttpOnly: true,
-				Path:     "/auth/isAdmin",
-				Secure:   true,
-				SameSite: http.SameSiteNoneMode,
-			})
-
-			log.Info("checked if user is admin")
-
-			w.WriteHeader(http.StatusAccepted)
-
-			render.JSON(w, r, Response{
-				Response: resp.OK(),
-				IsAdmin:  isAdmin,
-			})
-		} else {
-			http.SetCookie(w, &http.Cookie{
-				Name:     "isAdmin",
-				Value:    "false",
-				HttpOnly: true,
-				Path:     "/auth/isAdmin",
-				Secure:   true,
-				SameSite: http.SameSiteNoneMode,
-			})
-			log.Info("checked if user is admin")
-
-			w.WriteHeader(http.StatusForbidden)
-
-			render.JSON(w, r, Response{
-				Response: resp.OK(),
-				IsAdmin:  isAdmin,
-			})
-		}
-	}
+	render.JSON(w, r, Response{
+		Response: resp.OK(),
+		IsAdmin:  isAdmin,
+	})
 }
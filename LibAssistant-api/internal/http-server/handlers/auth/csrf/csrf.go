@@ -0,0 +1,27 @@
+package csrf
+
+import (
+	MWCsrf "LibAssistant_api/internal/http-server/middleware/csrf"
+	MWJwt "LibAssistant_api/internal/http-server/middleware/jwt"
+	"LibAssistant_api/internal/lib/api/response"
+	"context"
+	"net/http"
+
+	"github.com/go-chi/render"
+)
+
+type Response struct {
+	resp.Response
+	CSRFToken string `json:"csrfToken"`
+}
+
+// New returns the CSRF token of the caller's session again, e.g. after a page
+// reload, so a frontend on another site need not keep it from the login response.
+func New(ctx context.Context, appSecret string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		render.JSON(w, r, Response{
+			Response:  resp.OK(),
+			CSRFToken: MWCsrf.Token(appSecret, MWJwt.TokenFromRequest(r)),
+		})
+	}
+}
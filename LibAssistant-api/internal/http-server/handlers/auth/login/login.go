@@ -2,16 +2,17 @@ package login
 
 import (
 	ssogrpc "LibAssistant_api/internal/clients/sso/grpc"
+	MWCsrf "LibAssistant_api/internal/http-server/middleware/csrf"
+	MWJwt "LibAssistant_api/internal/http-server/middleware/jwt"
+	"LibAssistant_api/internal/lib/api/problem"
+	"LibAssistant_api/internal/lib/api/request"
 	"LibAssistant_api/internal/lib/api/response"
-	"LibAssistant_api/internal/lib/logger/sl"
 	"context"
-	"errors"
 	"log/slog"
 	"net/http"
 
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
-	"github.com/go-playground/validator/v10"
 )
 
 type Request struct {
@@ -21,96 +22,55 @@ type Request struct {
 
 type Response struct {
 	resp.Response
-	Token string  `json:"token"`
+	Token     string `json:"token"`
+	CSRFToken string `json:"csrfToken"`
 }
 
-func New(ctx context.Context, log *slog.Logger, ssoClient *ssogrpc.Client) http.HandlerFunc {
+// New logs the user in and sets the auth cookie together with its CSRF token, which
+// browser clients send back in the X-CSRF-Token header of state-changing requests.
+func New(ctx context.Context, log *slog.Logger, ssoClient *ssogrpc.Client, appSecret string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		const op = "handlers.Auth.Register.New"
+		const op = "handlers.Auth.Login.New"
 
-		log = log.With(
-			slog.String("op", op), 
+		log := log.With(
+			slog.String("op", op),
 			slog.String("request_id", middleware.GetReqID(r.Context())),
 		)
 
 		var req Request
 
-		if err := render.DecodeJSON(r.Body, &req); err != nil {
-			log.Error("failed to decode request body", sl.Err(err))
-
-			w.WriteHeader(http.StatusInternalServerError)
-
-			render.JSON(w, r, resp.Error("Unknown error"))
-
-			return
-		}
-
-		log.Info("request body decoded", slog.Any("request", req))
-
-		if err := validator.New().Struct(req); err != nil {
-			validationErr := err.(validator.ValidationErrors)
-
-			log.Error("invalid request", sl.Err(err))
-
-			w.WriteHeader(http.StatusBadRequest)
-
-			render.JSON(w, r, resp.Error("Invalid request"))
-			render.JSON(w, r, resp.ValidationError(validationErr))
+		if err := request.Decode(r, &req); err != nil {
+			problem.Render(w, r, log, err)
 
 			return
 		}
 
-		email := req.Email
-		password := req.Password
-
-		token, err := ssoClient.Login(ctx, email, password)
+		token, err := ssoClient.Login(r.Context(), req.Email, req.Password)
 		if err != nil {
-
-			if errors.Is(err, ssogrpc.ErrInvalidCredentials) {
-				log.Error("invalid credentials")
-
-				w.WriteHeader(http.StatusBadRequest)
-
-				render.JSON(w, r, resp.Error("Invalid email or password"))
-
-				return
-			} 
-
-			if errors.Is(err, ssogrpc.ErrInternal) {
-				log.Error("internal error")
-
-				w.WriteHeader(http.StatusInternalServerError)
-
-				render.JSON(w, r, resp.Error("Unknown internal error"))
-
-				return
-			}
-
-			log.Error("failed to log user in", sl.Err(err))
-
-			w.WriteHeader(http.StatusInternalServerError)
-
-			render.JSON(w, r, resp.Error("Unknown error"))
+			problem.Render(w, r, log, err)
 
 			return
 		}
 
 		http.SetCookie(w, &http.Cookie{
-			Name: "auth_token",
-			Value: token,
+			Name:     MWJwt.CookieName,
+			Value:    token,
 			SameSite: http.SameSiteNoneMode,
 			HttpOnly: true,
-			Path: "/auth/token",
-			Secure: true,
+			Path:     "/",
+			Secure:   true,
 		})
 
-		log.Info("user logged in successfully")
+		csrfToken := MWCsrf.SetCookie(w, appSecret, token)
+
+		log.InfoContext(r.Context(), "user logged in successfully")
 
-		w.WriteHeader(http.StatusCreated)
+		render.Status(r, http.StatusCreated)
 
 		render.JSON(w, r, Response{
-			Response: resp.OK(),
-			Token: token,
+			Response:  resp.OK(),
+			Token:     token,
+			CSRFToken: csrfToken,
 		})
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,62 @@
+package addBook
+
+import (
+	issuegrpc "LibAssistant_api/internal/clients/issue/grpc"
+	"LibAssistant_api/internal/lib/api/problem"
+	"LibAssistant_api/internal/lib/api/request"
+	resp "LibAssistant_api/internal/lib/api/response"
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+)
+
+type Request struct {
+	Title       string `json:"title" validate:"required,notblank,max=255"`
+	TotalCopies int32  `json:"totalCopies" validate:"required,gt=0,lte=10000"`
+}
+
+type Response struct {
+	resp.Response
+	BookID int64 `json:"bookID"`
+}
+
+func New(ctx context.Context, log *slog.Logger, issueClient *issuegrpc.Client) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.Books.AddBook.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		var req Request
+
+		if err := request.Decode(r, &req); err != nil {
+			problem.Render(w, r, log, err)
+
+			return
+		}
+
+		bookID, err := issueClient.AddBook(r.Context(), strings.TrimSpace(req.Title), req.TotalCopies)
+		if err != nil {
+			problem.Render(w, r, log, err)
+
+			return
+		}
+
+		log.InfoContext(r.Context(), "book added", slog.Int64("book_id", bookID))
+
+		w.Header().Set("Location", "/api/v1/admin/books/"+strconv.FormatInt(bookID, 10))
+		render.Status(r, http.StatusCreated)
+
+		render.JSON(w, r, Response{
+			Response: resp.OK(),
+			BookID:   bookID,
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package addBook
+
+import (
+	issuev1 "LibAssistant_api/gen/go/issue"
+	issuegrpc "LibAssistant_api/internal/clients/issue/grpc"
+	"LibAssistant_api/internal/clients/issue/grpc/issuegrpctest"
+	MWAuthz "LibAssistant_api/internal/http-server/middleware/authz"
+	MWJwt "LibAssistant_api/internal/http-server/middleware/jwt"
+	"LibAssistant_api/internal/lib/api/problem"
+	jwtValidation "LibAssistant_api/internal/lib/jwt"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeService records the books it is asked to add.
+type fakeService struct {
+	issuev1.UnimplementedIssueServiceServer
+
+	requests []*issuev1.AddBookRequest
+}
+
+func (f *fakeService) AddBook(_ context.Context, req *issuev1.AddBookRequest) (*issuev1.AddBookResponse, error) {
+	f.requests = append(f.requests, req)
+
+	return &issuev1.AddBookResponse{Success: true, BookId: 12}, nil
+}
+
+// noRefresh fails the test's claims refresh; the claims below are always fresh.
+type noRefresh struct{}
+
+func (noRefresh) IsAdmin(context.Context, int64) (bool, error) {
+	return false, errors.New("unexpected refresh")
+}
+
+func TestAddBook(t *testing.T) {
+	admin := &jwtValidation.Claims{UserID: 1, Roles: []string{jwtValidation.RoleAdmin}, IssuedAt: time.Now()}
+	student := &jwtValidation.Claims{UserID: 7, Roles: []string{jwtValidation.RoleStudent}, IssuedAt: time.Now()}
+
+	tests := []struct {
+		name       string
+		claims     *jwtValidation.Claims
+		body       string
+		wantStatus int
+		wantCode   string
+		wantTitle  string
+	}{
+		{
+			name:       "added",
+			claims:     admin,
+			body:       `{"title": "  Dune ", "totalCopies": 3}`,
+			wantStatus: http.StatusCreated,
+			wantTitle:  "Dune",
+		},
+		{
+			name:       "blank title",
+			claims:     admin,
+			body:       `{"title": "   ", "totalCopies": 3}`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   problem.CodeValidationFailed,
+		},
+		{
+			name:       "students cannot manage the catalog",
+			claims:     student,
+			body:       `{"title": "Dune", "totalCopies": 3}`,
+			wantStatus: http.StatusForbidden,
+			wantCode:   problem.CodeForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log := slog.New(slog.DiscardHandler)
+
+			srv := &fakeService{}
+			cfg, dialer := issuegrpctest.Serve(t, srv)
+
+			client, err := issuegrpc.New(context.Background(), log, cfg, dialer)
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = client.Close() })
+
+			// The route requires catalog:manage, as registered in cmd/LibAssistant-api.
+			authz := MWAuthz.New(log, noRefresh{}, time.Minute)
+			handler := authz.RequirePermissions(MWAuthz.PermCatalogManage)(New(context.Background(), log, client))
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/books", strings.NewReader(tt.body))
+			req = req.WithContext(MWJwt.WithClaims(req.Context(), tt.claims))
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			require.Equal(t, tt.wantStatus, rec.Code)
+
+			if tt.wantCode != "" {
+				assert.Empty(t, srv.requests, "the issue service is not called")
+				assert.Equal(t, problem.ContentType, rec.Header().Get("Content-Type"))
+
+				var p problem.Problem
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &p))
+				assert.Equal(t, tt.wantCode, p.Code)
+
+				return
+			}
+
+			require.Len(t, srv.requests, 1)
+			assert.Equal(t, tt.wantTitle, srv.requests[0].GetTitle())
+
+			assert.Equal(t, "/api/v1/admin/books/12", rec.Header().Get("Location"))
+			assert.True(t, strings.HasPrefix(rec.Header().Get("Content-Type"), "application/json"))
+
+			var resp Response
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+			assert.Equal(t, int64(12), resp.BookID)
+		})
+	}
+}
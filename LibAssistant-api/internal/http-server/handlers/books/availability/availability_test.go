@@ -0,0 +1,81 @@
+package availability
+
+import (
+	issuev1 "LibAssistant_api/gen/go/issue"
+	issuegrpc "LibAssistant_api/internal/clients/issue/grpc"
+	"LibAssistant_api/internal/clients/issue/grpc/issuegrpctest"
+	"LibAssistant_api/internal/lib/api/problem"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeService has 2 of 3 copies of book 5 available; like the real service it
+// answers 0 of 0 for unknown books.
+type fakeService struct {
+	issuev1.UnimplementedIssueServiceServer
+}
+
+func (fakeService) CheckAvailability(_ context.Context, req *issuev1.CheckAvailabilityRequest) (*issuev1.CheckAvailabilityResponse, error) {
+	if req.GetBookId() != 5 {
+		return &issuev1.CheckAvailabilityResponse{}, nil
+	}
+
+	return &issuev1.CheckAvailabilityResponse{AvailableCopies: 2, TotalCopies: 3}, nil
+}
+
+func TestAvailability(t *testing.T) {
+	log := slog.New(slog.DiscardHandler)
+
+	cfg, dialer := issuegrpctest.Serve(t, fakeService{})
+
+	client, err := issuegrpc.New(context.Background(), log, cfg, dialer)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+
+	router := chi.NewRouter()
+	router.Get("/api/v1/books/{id}/availability", New(context.Background(), log, client))
+
+	tests := []struct {
+		name       string
+		id         string
+		wantStatus int
+		wantCode   string
+	}{
+		{name: "known book", id: "5", wantStatus: http.StatusOK},
+		{name: "unknown book", id: "6", wantStatus: http.StatusNotFound, wantCode: problem.CodeBookNotFound},
+		{name: "invalid id", id: "0", wantStatus: http.StatusBadRequest, wantCode: problem.CodeValidationFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/books/"+tt.id+"/availability", nil))
+
+			require.Equal(t, tt.wantStatus, rec.Code)
+
+			if tt.wantCode != "" {
+				assert.Equal(t, problem.ContentType, rec.Header().Get("Content-Type"))
+
+				var p problem.Problem
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &p))
+				assert.Equal(t, tt.wantCode, p.Code)
+
+				return
+			}
+
+			var resp Response
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+			assert.Equal(t, int64(5), resp.BookID)
+			assert.Equal(t, int32(2), resp.AvailableCopies)
+			assert.Equal(t, int32(3), resp.TotalCopies)
+		})
+	}
+}
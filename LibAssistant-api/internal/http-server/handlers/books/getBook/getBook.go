@@ -0,0 +1,55 @@
+package getBook
+
+import (
+	issuegrpc "LibAssistant_api/internal/clients/issue/grpc"
+	"LibAssistant_api/internal/lib/api/problem"
+	"LibAssistant_api/internal/lib/api/request"
+	resp "LibAssistant_api/internal/lib/api/response"
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+)
+
+type Response struct {
+	resp.Response
+	BookID          int64  `json:"bookID"`
+	Title           string `json:"title"`
+	TotalCopies     int32  `json:"totalCopies"`
+	AvailableCopies int32  `json:"availableCopies"`
+}
+
+func New(ctx context.Context, log *slog.Logger, issueClient *issuegrpc.Client) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.Books.GetBook.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		bookID, err := request.PathID(r, "id")
+		if err != nil {
+			problem.Render(w, r, log, err)
+
+			return
+		}
+
+		book, err := issueClient.GetBook(r.Context(), bookID)
+		if err != nil {
+			problem.Render(w, r, log, err)
+
+			return
+		}
+
+		render.JSON(w, r, Response{
+			Response:        resp.OK(),
+			BookID:          book.ID,
+			Title:           book.Title,
+			TotalCopies:     book.TotalCopies,
+			AvailableCopies: book.AvailableCopies,
+		})
+	}
+}
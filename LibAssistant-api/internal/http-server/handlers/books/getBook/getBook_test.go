@@ -0,0 +1,110 @@
+package getBook
+
+import (
+	issuev1 "LibAssistant_api/gen/go/issue"
+	issuegrpc "LibAssistant_api/internal/clients/issue/grpc"
+	"LibAssistant_api/internal/clients/issue/grpc/issuegrpctest"
+	MWAuthz "LibAssistant_api/internal/http-server/middleware/authz"
+	MWJwt "LibAssistant_api/internal/http-server/middleware/jwt"
+	"LibAssistant_api/internal/lib/api/problem"
+	jwtValidation "LibAssistant_api/internal/lib/jwt"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeService knows book 5 only and counts the lookups.
+type fakeService struct {
+	issuev1.UnimplementedIssueServiceServer
+
+	calls int
+}
+
+func (f *fakeService) GetBook(_ context.Context, req *issuev1.GetBookRequest) (*issuev1.GetBookResponse, error) {
+	f.calls++
+
+	if req.GetBookId() != 5 {
+		return nil, status.Error(codes.NotFound, "book not found")
+	}
+
+	return &issuev1.GetBookResponse{Success: true, BookId: 5, Title: "Dune", TotalCopies: 3, AvailableCopies: 1}, nil
+}
+
+// noRefresh fails the test's claims refresh; the claims below are always fresh.
+type noRefresh struct{}
+
+func (noRefresh) IsAdmin(context.Context, int64) (bool, error) {
+	return false, errors.New("unexpected refresh")
+}
+
+func TestGetBook(t *testing.T) {
+	admin := &jwtValidation.Claims{UserID: 1, Roles: []string{jwtValidation.RoleAdmin}, IssuedAt: time.Now()}
+	student := &jwtValidation.Claims{UserID: 7, Roles: []string{jwtValidation.RoleStudent}, IssuedAt: time.Now()}
+
+	tests := []struct {
+		name       string
+		claims     *jwtValidation.Claims
+		id         string
+		wantStatus int
+		wantCode   string
+		wantCalls  int
+	}{
+		{name: "found", claims: admin, id: "5", wantStatus: http.StatusOK, wantCalls: 1},
+		{name: "not found", claims: admin, id: "6", wantStatus: http.StatusNotFound, wantCode: problem.CodeBookNotFound, wantCalls: 1},
+		{name: "invalid id", claims: admin, id: "abc", wantStatus: http.StatusBadRequest, wantCode: problem.CodeValidationFailed},
+		{name: "students cannot view catalog records", claims: student, id: "5", wantStatus: http.StatusForbidden, wantCode: problem.CodeForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log := slog.New(slog.DiscardHandler)
+
+			srv := &fakeService{}
+			cfg, dialer := issuegrpctest.Serve(t, srv)
+
+			client, err := issuegrpc.New(context.Background(), log, cfg, dialer)
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = client.Close() })
+
+			// The route requires catalog:manage, as registered in cmd/LibAssistant-api.
+			authz := MWAuthz.New(log, noRefresh{}, time.Minute)
+
+			router := chi.NewRouter()
+			router.With(authz.RequirePermissions(MWAuthz.PermCatalogManage)).Get("/api/v1/admin/books/{id}", New(context.Background(), log, client))
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/books/"+tt.id, nil)
+			req = req.WithContext(MWJwt.WithClaims(req.Context(), tt.claims))
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			require.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, tt.wantCalls, srv.calls)
+
+			if tt.wantCode != "" {
+				assert.Equal(t, problem.ContentType, rec.Header().Get("Content-Type"))
+
+				var p problem.Problem
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &p))
+				assert.Equal(t, tt.wantCode, p.Code)
+
+				return
+			}
+
+			var resp Response
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+			assert.Equal(t, Response{Response: resp.Response, BookID: 5, Title: "Dune", TotalCopies: 3, AvailableCopies: 1}, resp)
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package allDebts
+
+import (
+	issuegrpc "LibAssistant_api/internal/clients/issue/grpc"
+	"LibAssistant_api/internal/http-server/handlers/debts"
+	"LibAssistant_api/internal/lib/api/export"
+	"LibAssistant_api/internal/lib/api/problem"
+	resp "LibAssistant_api/internal/lib/api/response"
+	"LibAssistant_api/internal/lib/logger/sl"
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+)
+
+type Response struct {
+	resp.Response
+	Debts []debts.Debt `json:"debts"`
+}
+
+func New(ctx context.Context, log *slog.Logger, issueClient *issuegrpc.Client) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.Debts.AllDebts.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		format, err := export.Negotiate(r)
+		if err != nil {
+			problem.Render(w, r, log, err)
+
+			return
+		}
+
+		query, err := debts.ParseQuery(r)
+		if err != nil {
+			problem.Render(w, r, log, problem.InvalidQuery(err.Error(), err))
+
+			return
+		}
+
+		list, err := issueClient.GetAllDebts(r.Context())
+		if err != nil {
+			problem.Render(w, r, log, err)
+
+			return
+		}
+
+		list = query.Apply(list)
+
+		if format != export.JSON {
+			if err := debts.WriteFile(w, format, "debts", list); err != nil {
+				log.ErrorContext(r.Context(), "failed to write debts file", sl.Err(err))
+			}
+
+			return
+		}
+
+		render.JSON(w, r, Response{
+			Response: resp.OK(),
+			Debts:    debts.FromClient(list),
+		})
+	}
+}
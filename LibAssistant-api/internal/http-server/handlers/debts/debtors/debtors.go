@@ -0,0 +1,72 @@
+package debtors
+
+import (
+	issuegrpc "LibAssistant_api/internal/clients/issue/grpc"
+	"LibAssistant_api/internal/http-server/handlers/debts"
+	"LibAssistant_api/internal/lib/api/export"
+	"LibAssistant_api/internal/lib/api/problem"
+	resp "LibAssistant_api/internal/lib/api/response"
+	"LibAssistant_api/internal/lib/logger/sl"
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+)
+
+type Response struct {
+	resp.Response
+	Debts     []debts.Debt `json:"debts"`
+	FromCache bool         `json:"fromCache"`
+}
+
+// New lists debtors through the issue service's cached ViewDebtors. The whole list
+// is fetched and limited here, after filtering, so ?limit= counts matching debts.
+func New(ctx context.Context, log *slog.Logger, issueClient *issuegrpc.Client) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.Debts.Debtors.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		format, err := export.Negotiate(r)
+		if err != nil {
+			problem.Render(w, r, log, err)
+
+			return
+		}
+
+		query, err := debts.ParseQuery(r)
+		if err != nil {
+			problem.Render(w, r, log, problem.InvalidQuery(err.Error(), err))
+
+			return
+		}
+
+		list, fromCache, err := issueClient.ViewDebtors(r.Context(), 0)
+		if err != nil {
+			problem.Render(w, r, log, err)
+
+			return
+		}
+
+		list = query.Apply(list)
+
+		if format != export.JSON {
+			if err := debts.WriteFile(w, format, "debtors", list); err != nil {
+				log.ErrorContext(r.Context(), "failed to write debtors file", sl.Err(err))
+			}
+
+			return
+		}
+
+		render.JSON(w, r, Response{
+			Response:  resp.OK(),
+			Debts:     debts.FromClient(list),
+			FromCache: fromCache,
+		})
+	}
+}
@@ -0,0 +1,160 @@
+// Package debts holds what the debt listings share: query parsing, filtering and
+// sorting in the gateway, and the JSON and file representations of a debt.
+package debts
+
+import (
+	issuegrpc "LibAssistant_api/internal/clients/issue/grpc"
+	"LibAssistant_api/internal/lib/api/export"
+	"cmp"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+)
+
+const (
+	SortFine        = "fine"
+	SortOverdueDays = "overdue_days"
+)
+
+var ErrInvalidQuery = errors.New("invalid query")
+
+// Query is ?student_id=&sort=fine|overdue_days&order=asc|desc&limit=. Sorting is
+// by fine, largest first, unless asked otherwise; limit 0 means no limit.
+type Query struct {
+	StudentID int64
+	Sort      string
+	Desc      bool
+	Limit     int
+}
+
+func ParseQuery(r *http.Request) (Query, error) {
+	values := r.URL.Query()
+
+	q := Query{
+		Sort: SortFine,
+		Desc: true,
+	}
+
+	if v := values.Get("student_id"); v != "" {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || id <= 0 {
+			return q, fmt.Errorf("%w: student_id must be a positive integer", ErrInvalidQuery)
+		}
+
+		q.StudentID = id
+	}
+
+	if v := values.Get("sort"); v != "" {
+		if v != SortFine && v != SortOverdueDays {
+			return q, fmt.Errorf("%w: sort must be %s or %s", ErrInvalidQuery, SortFine, SortOverdueDays)
+		}
+
+		q.Sort = v
+	}
+
+	switch values.Get("order") {
+	case "", "desc":
+	case "asc":
+		q.Desc = false
+	default:
+		return q, fmt.Errorf("%w: order must be asc or desc", ErrInvalidQuery)
+	}
+
+	if v := values.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit < 0 {
+			return q, fmt.Errorf("%w: limit must be a non-negative integer", ErrInvalidQuery)
+		}
+
+		q.Limit = limit
+	}
+
+	return q, nil
+}
+
+// Apply filters, sorts and limits list in place and returns the result. Ties are
+// broken by loan id, so repeated downloads list debts in the same order.
+func (q Query) Apply(list []issuegrpc.Debt) []issuegrpc.Debt {
+	if q.StudentID != 0 {
+		list = slices.DeleteFunc(list, func(d issuegrpc.Debt) bool {
+			return d.StudentID != q.StudentID
+		})
+	}
+
+	key := func(d issuegrpc.Debt) int32 { return d.Fine }
+	if q.Sort == SortOverdueDays {
+		key = func(d issuegrpc.Debt) int32 { return d.OverdueDays }
+	}
+
+	slices.SortStableFunc(list, func(a, b issuegrpc.Debt) int {
+		c := cmp.Compare(key(a), key(b))
+		if q.Desc {
+			c = -c
+		}
+
+		return cmp.Or(c, cmp.Compare(a.LoanID, b.LoanID))
+	})
+
+	if q.Limit > 0 && len(list) > q.Limit {
+		list = list[:q.Limit]
+	}
+
+	return list
+}
+
+type Debt struct {
+	LoanID      int64      `json:"loanID"`
+	StudentID   int64      `json:"studentID"`
+	StudentName string     `json:"studentName"`
+	BookID      int64      `json:"bookID"`
+	BookTitle   string     `json:"bookTitle"`
+	DueDate     *time.Time `json:"dueDate,omitempty"`
+	OverdueDays int32      `json:"overdueDays"`
+	Fine        int32      `json:"fine"`
+}
+
+func FromClient(list []issuegrpc.Debt) []Debt {
+	out := make([]Debt, 0, len(list))
+
+	for _, d := range list {
+		debt := Debt{
+			LoanID:      d.LoanID,
+			StudentID:   d.StudentID,
+			StudentName: d.StudentName,
+			BookID:      d.BookID,
+			BookTitle:   d.BookTitle,
+			OverdueDays: d.OverdueDays,
+			Fine:        d.Fine,
+		}
+
+		if !d.DueDate.IsZero() {
+			debt.DueDate = &d.DueDate
+		}
+
+		out = append(out, debt)
+	}
+
+	return out
+}
+
+// WriteFile sends list as a CSV or XLSX download named after name and today's date.
+func WriteFile(w http.ResponseWriter, format export.Format, name string, list []issuegrpc.Debt) error {
+	t := export.Table{
+		Header: []string{"Loan ID", "Student ID", "Student", "Book ID", "Book", "Due date", "Overdue days", "Fine"},
+	}
+
+	for _, d := range list {
+		t.Rows = append(t.Rows, []any{d.LoanID, d.StudentID, d.StudentName, d.BookID, d.BookTitle, d.DueDate, d.OverdueDays, d.Fine})
+	}
+
+	filename := name + "-" + time.Now().Format(time.DateOnly)
+
+	if format == export.XLSX {
+		return export.WriteXLSX(w, filename+".xlsx", name, t)
+	}
+
+	return export.WriteCSV(w, filename+".csv", t)
+}
@@ -0,0 +1,87 @@
+package debts
+
+import (
+	issuegrpc "LibAssistant_api/internal/clients/issue/grpc"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseQuery(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		want    Query
+		wantErr bool
+	}{
+		{name: "defaults", query: "", want: Query{Sort: SortFine, Desc: true}},
+		{
+			name:  "all set",
+			query: "?student_id=7&sort=overdue_days&order=asc&limit=3",
+			want:  Query{StudentID: 7, Sort: SortOverdueDays, Limit: 3},
+		},
+		{name: "explicit desc", query: "?order=desc", want: Query{Sort: SortFine, Desc: true}},
+		{name: "limit zero means all", query: "?limit=0", want: Query{Sort: SortFine, Desc: true}},
+		{name: "student_id not a number", query: "?student_id=abc", wantErr: true},
+		{name: "student_id zero", query: "?student_id=0", wantErr: true},
+		{name: "student_id negative", query: "?student_id=-1", wantErr: true},
+		{name: "unknown sort", query: "?sort=title", wantErr: true},
+		{name: "unknown order", query: "?order=up", wantErr: true},
+		{name: "negative limit", query: "?limit=-1", wantErr: true},
+		{name: "limit not a number", query: "?limit=ten", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseQuery(httptest.NewRequest("GET", "/api/v1/admin/debts"+tt.query, nil))
+
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrInvalidQuery)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestQueryApply(t *testing.T) {
+	list := func() []issuegrpc.Debt {
+		return []issuegrpc.Debt{
+			{LoanID: 1, StudentID: 10, Fine: 50, OverdueDays: 5},
+			{LoanID: 2, StudentID: 20, Fine: 200, OverdueDays: 2},
+			{LoanID: 3, StudentID: 10, Fine: 50, OverdueDays: 9},
+			{LoanID: 4, StudentID: 30, Fine: 0, OverdueDays: 1},
+		}
+	}
+
+	tests := []struct {
+		name  string
+		query Query
+		want  []int64
+	}{
+		{name: "by fine desc, ties by loan id", query: Query{Sort: SortFine, Desc: true}, want: []int64{2, 1, 3, 4}},
+		{name: "by fine asc", query: Query{Sort: SortFine}, want: []int64{4, 1, 3, 2}},
+		{name: "by overdue days desc", query: Query{Sort: SortOverdueDays, Desc: true}, want: []int64{3, 1, 2, 4}},
+		{name: "by overdue days asc", query: Query{Sort: SortOverdueDays}, want: []int64{4, 2, 1, 3}},
+		{name: "one student", query: Query{StudentID: 10, Sort: SortFine, Desc: true}, want: []int64{1, 3}},
+		{name: "unknown student", query: Query{StudentID: 99, Sort: SortFine, Desc: true}, want: []int64{}},
+		{name: "limit", query: Query{Sort: SortFine, Desc: true, Limit: 2}, want: []int64{2, 1}},
+		{name: "limit above length", query: Query{Sort: SortFine, Desc: true, Limit: 10}, want: []int64{2, 1, 3, 4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := []int64{}
+			for _, d := range tt.query.Apply(list()) {
+				got = append(got, d.LoanID)
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
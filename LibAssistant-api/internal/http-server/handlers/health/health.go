@@ -0,0 +1,40 @@
+package health
+
+import (
+	"LibAssistant_api/internal/lib/api/problem"
+	"LibAssistant_api/internal/lib/api/response"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/go-chi/render"
+)
+
+// Readiness is flipped to not ready at the start of shutdown, so load balancers
+// stop routing new requests before the server drains the in-flight ones.
+type Readiness struct {
+	ready atomic.Bool
+}
+
+func (r *Readiness) SetReady(ready bool) {
+	r.ready.Store(ready)
+}
+
+// Live reports that the process is up; it stays OK during shutdown.
+func Live() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		render.JSON(w, r, resp.OK())
+	}
+}
+
+// Ready reports 503 until the gateway is ready and again once shutdown starts.
+func Ready(readiness *Readiness) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !readiness.ready.Load() {
+			problem.Write(w, r, problem.New(http.StatusServiceUnavailable, problem.CodeUnavailable, "Not ready"))
+
+			return
+		}
+
+		render.JSON(w, r, resp.OK())
+	}
+}
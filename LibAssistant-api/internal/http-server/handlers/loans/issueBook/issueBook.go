@@ -0,0 +1,63 @@
+package issueBook
+
+import (
+	issuegrpc "LibAssistant_api/internal/clients/issue/grpc"
+	"LibAssistant_api/internal/lib/api/problem"
+	"LibAssistant_api/internal/lib/api/request"
+	resp "LibAssistant_api/internal/lib/api/response"
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+)
+
+// Request.StudentID is the issue service's student id. It is unrelated to SSO user
+// ids, so it cannot default to the caller.
+type Request struct {
+	BookID    int64 `json:"bookID" validate:"required,gt=0"`
+	StudentID int64 `json:"studentID" validate:"required,gt=0"`
+	DaysDue   int32 `json:"daysDue" validate:"omitempty,gt=0,lte=365"`
+}
+
+type Response struct {
+	resp.Response
+	LoanID int64 `json:"loanID"`
+}
+
+// New issues books on behalf of librarians; the route requires the loans:manage permission.
+func New(ctx context.Context, log *slog.Logger, issueClient *issuegrpc.Client) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.Loans.IssueBook.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		var req Request
+
+		if err := request.Decode(r, &req); err != nil {
+			problem.Render(w, r, log, err)
+
+			return
+		}
+
+		loanID, err := issueClient.IssueBook(r.Context(), req.BookID, req.StudentID, req.DaysDue)
+		if err != nil {
+			problem.Render(w, r, log, err)
+
+			return
+		}
+
+		log.InfoContext(r.Context(), "book issued", slog.Int64("loan_id", loanID), slog.Int64("student_id", req.StudentID))
+
+		render.Status(r, http.StatusCreated)
+
+		render.JSON(w, r, Response{
+			Response: resp.OK(),
+			LoanID:   loanID,
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package issueBook
+
+import (
+	issuev1 "LibAssistant_api/gen/go/issue"
+	issuegrpc "LibAssistant_api/internal/clients/issue/grpc"
+	"LibAssistant_api/internal/clients/issue/grpc/issuegrpctest"
+	MWAuthz "LibAssistant_api/internal/http-server/middleware/authz"
+	MWJwt "LibAssistant_api/internal/http-server/middleware/jwt"
+	"LibAssistant_api/internal/lib/api/problem"
+	jwtValidation "LibAssistant_api/internal/lib/jwt"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeService records the loans it is asked for and fails them with message, if set.
+type fakeService struct {
+	issuev1.UnimplementedIssueServiceServer
+
+	message  string
+	requests []*issuev1.IssueRequest
+}
+
+func (f *fakeService) IssueBook(_ context.Context, req *issuev1.IssueRequest) (*issuev1.IssueResponse, error) {
+	f.requests = append(f.requests, req)
+
+	if f.message != "" {
+		return &issuev1.IssueResponse{Message: f.message}, nil
+	}
+
+	return &issuev1.IssueResponse{Success: true, IssueId: 42}, nil
+}
+
+// noRefresh fails the test's claims refresh; the claims below are always fresh.
+type noRefresh struct{}
+
+func (noRefresh) IsAdmin(context.Context, int64) (bool, error) {
+	return false, errors.New("unexpected refresh")
+}
+
+func TestIssueBook(t *testing.T) {
+	admin := &jwtValidation.Claims{UserID: 1, Roles: []string{jwtValidation.RoleAdmin}, IssuedAt: time.Now()}
+	student := &jwtValidation.Claims{UserID: 7, Roles: []string{jwtValidation.RoleStudent}, IssuedAt: time.Now()}
+
+	tests := []struct {
+		name       string
+		claims     *jwtValidation.Claims
+		body       string
+		message    string
+		wantStatus int
+		wantCode   string
+		wantIssued *issuev1.IssueRequest
+	}{
+		{
+			name:       "issued",
+			claims:     admin,
+			body:       `{"bookID": 3, "studentID": 9, "daysDue": 7}`,
+			wantStatus: http.StatusCreated,
+			wantIssued: &issuev1.IssueRequest{BookId: 3, StudentId: 9, DaysDue: 7},
+		},
+		{
+			name:       "student id is required",
+			claims:     admin,
+			body:       `{"bookID": 3}`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   problem.CodeValidationFailed,
+		},
+		{
+			name:       "students cannot issue books, not even to themselves",
+			claims:     student,
+			body:       `{"bookID": 3, "studentID": 7}`,
+			wantStatus: http.StatusForbidden,
+			wantCode:   problem.CodeForbidden,
+		},
+		{
+			name:       "no copies left",
+			claims:     admin,
+			body:       `{"bookID": 3, "studentID": 9}`,
+			message:    "No available copies",
+			wantStatus: http.StatusConflict,
+			wantCode:   problem.CodeNoCopies,
+			wantIssued: &issuev1.IssueRequest{BookId: 3, StudentId: 9},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log := slog.New(slog.DiscardHandler)
+
+			srv := &fakeService{message: tt.message}
+			cfg, dialer := issuegrpctest.Serve(t, srv)
+
+			client, err := issuegrpc.New(context.Background(), log, cfg, dialer)
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = client.Close() })
+
+			// The route requires loans:manage, as registered in cmd/LibAssistant-api.
+			authz := MWAuthz.New(log, noRefresh{}, time.Minute)
+			handler := authz.RequirePermissions(MWAuthz.PermLoansManage)(New(context.Background(), log, client))
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/loans", strings.NewReader(tt.body))
+			req = req.WithContext(MWJwt.WithClaims(req.Context(), tt.claims))
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			require.Equal(t, tt.wantStatus, rec.Code)
+
+			if tt.wantIssued != nil {
+				require.Len(t, srv.requests, 1)
+				assert.Equal(t, tt.wantIssued.GetBookId(), srv.requests[0].GetBookId())
+				assert.Equal(t, tt.wantIssued.GetStudentId(), srv.requests[0].GetStudentId())
+				assert.Equal(t, tt.wantIssued.GetDaysDue(), srv.requests[0].GetDaysDue())
+			} else {
+				assert.Empty(t, srv.requests, "the issue service is not called")
+			}
+
+			if tt.wantCode != "" {
+				assert.Equal(t, problem.ContentType, rec.Header().Get("Content-Type"))
+
+				var p problem.Problem
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &p))
+				assert.Equal(t, tt.wantCode, p.Code)
+
+				return
+			}
+
+			assert.True(t, strings.HasPrefix(rec.Header().Get("Content-Type"), "application/json"))
+
+			var resp Response
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+			assert.Equal(t, int64(42), resp.LoanID)
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package reportLost
+
+import (
+	issuegrpc "LibAssistant_api/internal/clients/issue/grpc"
+	"LibAssistant_api/internal/lib/api/problem"
+	"LibAssistant_api/internal/lib/api/request"
+	resp "LibAssistant_api/internal/lib/api/response"
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+)
+
+type Response struct {
+	resp.Response
+	Fine int32 `json:"fine"`
+}
+
+func New(ctx context.Context, log *slog.Logger, issueClient *issuegrpc.Client) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.Loans.ReportLost.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		loanID, err := request.PathID(r, "id")
+		if err != nil {
+			problem.Render(w, r, log, err)
+
+			return
+		}
+
+		fine, err := issueClient.ReportLost(r.Context(), loanID)
+		if err != nil {
+			problem.Render(w, r, log, err)
+
+			return
+		}
+
+		log.InfoContext(r.Context(), "book reported lost", slog.Int64("loan_id", loanID), slog.Int("fine", int(fine)))
+
+		render.JSON(w, r, Response{
+			Response: resp.OK(),
+			Fine:     fine,
+		})
+	}
+}
@@ -0,0 +1,196 @@
+package MWAuthz
+
+import (
+	ssogrpc "LibAssistant_api/internal/clients/sso/grpc"
+	MWJwt "LibAssistant_api/internal/http-server/middleware/jwt"
+	"LibAssistant_api/internal/lib/api/problem"
+	jwtValidation "LibAssistant_api/internal/lib/jwt"
+	"LibAssistant_api/internal/lib/logger/sl"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+type Permission string
+
+const (
+	// PermUsersRead allows looking up other users, e.g. whether they are admins.
+	PermUsersRead Permission = "users:read"
+	// PermLoansManage allows issuing books to other students and closing any loan.
+	PermLoansManage Permission = "loans:manage"
+	// PermCatalogManage allows adding books and viewing catalog records.
+	PermCatalogManage Permission = "catalog:manage"
+	// PermDebtsRead allows listing and exporting every student's debts.
+	PermDebtsRead Permission = "debts:read"
+)
+
+// rolePermissions grants permissions to roles; a user has the union over their roles.
+var rolePermissions = map[string][]Permission{
+	jwtValidation.RoleAdmin: {
+		PermUsersRead,
+		PermLoansManage,
+		PermCatalogManage,
+		PermDebtsRead,
+	},
+	jwtValidation.RoleStudent: {},
+}
+
+// RoleChecker asks SSO for a user's current role.
+type RoleChecker interface {
+	IsAdmin(ctx context.Context, userID int64) (bool, error)
+}
+
+// Authorizer decides access from the verified claims MWJwt put into the request
+// context. Roles in a token are a snapshot taken at login, so claims older than
+// maxAge, or without roles at all, are refreshed from SSO before they are trusted.
+type Authorizer struct {
+	log     *slog.Logger
+	checker RoleChecker
+	maxAge  time.Duration
+}
+
+func New(log *slog.Logger, checker RoleChecker, maxAge time.Duration) *Authorizer {
+	return &Authorizer{
+		log: log.With(
+			slog.String("component", "middleware/authz"),
+		),
+		checker: checker,
+		maxAge:  maxAge,
+	}
+}
+
+// RequireRoles lets a request through if the caller has any of roles.
+func (a *Authorizer) RequireRoles(roles ...string) func(next http.Handler) http.Handler {
+	required := "role " + strings.Join(roles, "|")
+
+	return a.require(required, func(claims *jwtValidation.Claims) bool {
+		return slices.ContainsFunc(roles, claims.HasRole)
+	})
+}
+
+// RequirePermissions lets a request through if the caller's roles grant all of perms.
+func (a *Authorizer) RequirePermissions(perms ...Permission) func(next http.Handler) http.Handler {
+	names := make([]string, 0, len(perms))
+	for _, p := range perms {
+		names = append(names, string(p))
+	}
+
+	required := "permission " + strings.Join(names, ",")
+
+	return a.require(required, func(claims *jwtValidation.Claims) bool {
+		for _, p := range perms {
+			if !Can(claims, p) {
+				return false
+			}
+		}
+
+		return true
+	})
+}
+
+func (a *Authorizer) require(required string, allowed func(*jwtValidation.Claims) bool) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			claims, ok := a.Authorize(w, r)
+			if !ok {
+				return
+			}
+
+			if !allowed(claims) {
+				a.Deny(w, r, claims, required)
+
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(MWJwt.WithClaims(r.Context(), claims)))
+		})
+	}
+}
+
+// Authorize returns the caller's claims with roles that are fresh enough to decide
+// on. If it returns false, a response has already been written.
+func (a *Authorizer) Authorize(w http.ResponseWriter, r *http.Request) (*jwtValidation.Claims, bool) {
+	log := a.log.With(slog.String("request_id", middleware.GetReqID(r.Context())))
+
+	claims, ok := MWJwt.ClaimsFromContext(r.Context())
+	if !ok {
+		log.ErrorContext(r.Context(), "authorization used without authentication")
+
+		problem.Write(w, r, problem.New(http.StatusUnauthorized, problem.CodeUnauthorized, "Access token is required"))
+
+		return nil, false
+	}
+
+	claims, err := a.refresh(r.Context(), claims)
+	if err != nil {
+		if errors.Is(err, ssogrpc.ErrUserNotFound) {
+			a.Deny(w, r, claims, "existing user")
+
+			return nil, false
+		}
+
+		log.ErrorContext(r.Context(), "failed to refresh roles", sl.Err(err))
+
+		problem.Write(w, r, problem.New(http.StatusServiceUnavailable, problem.CodeUnavailable, "Cannot verify permissions, try again later"))
+
+		return nil, false
+	}
+
+	return claims, true
+}
+
+// refresh returns claims unchanged if they are fresh, otherwise a copy with roles from SSO.
+func (a *Authorizer) refresh(ctx context.Context, claims *jwtValidation.Claims) (*jwtValidation.Claims, error) {
+	const op = "middleware.Authz.refresh"
+
+	if len(claims.Roles) > 0 && !claims.IssuedAt.IsZero() && time.Since(claims.IssuedAt) <= a.maxAge {
+		return claims, nil
+	}
+
+	isAdmin, err := a.checker.IsAdmin(ctx, claims.UserID)
+	if err != nil {
+		return claims, fmt.Errorf("%s: %w", op, err)
+	}
+
+	fresh := *claims
+	fresh.Roles = []string{jwtValidation.RoleStudent}
+	if isAdmin {
+		fresh.Roles = []string{jwtValidation.RoleAdmin}
+	}
+
+	return &fresh, nil
+}
+
+// Deny answers 403 and writes an audit record of who was refused what.
+func (a *Authorizer) Deny(w http.ResponseWriter, r *http.Request, claims *jwtValidation.Claims, required string) {
+	a.log.WarnContext(r.Context(), "access denied",
+		slog.String("audit", "authz.denied"),
+		slog.Int64("user_id", claims.UserID),
+		slog.Any("roles", claims.Roles),
+		slog.String("required", required),
+		slog.String("method", r.Method),
+		slog.String("path", r.URL.Path),
+		slog.String("remote_addr", r.RemoteAddr),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	problem.Write(w, r, problem.New(http.StatusForbidden, problem.CodeForbidden, "You are not allowed to perform this action"))
+}
+
+// Can reports whether any of the roles in claims grants perm.
+func Can(claims *jwtValidation.Claims, perm Permission) bool {
+	for _, role := range claims.Roles {
+		if slices.Contains(rolePermissions[role], perm) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,223 @@
+package MWAuthz
+
+import (
+	ssogrpc "LibAssistant_api/internal/clients/sso/grpc"
+	MWJwt "LibAssistant_api/internal/http-server/middleware/jwt"
+	"LibAssistant_api/internal/lib/api/problem"
+	jwtValidation "LibAssistant_api/internal/lib/jwt"
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeChecker answers IsAdmin from admins and counts the calls.
+type fakeChecker struct {
+	admins map[int64]bool
+	err    error
+	calls  int
+}
+
+func (f *fakeChecker) IsAdmin(_ context.Context, userID int64) (bool, error) {
+	f.calls++
+
+	if f.err != nil {
+		return false, f.err
+	}
+
+	return f.admins[userID], nil
+}
+
+const maxAge = 5 * time.Minute
+
+func TestRequirePermissions(t *testing.T) {
+	fresh := time.Now().Add(-time.Minute)
+	stale := time.Now().Add(-2 * maxAge)
+
+	tests := []struct {
+		name       string
+		claims     *jwtValidation.Claims
+		checker    *fakeChecker
+		wantStatus int
+		wantCalls  int
+		wantRoles  []string
+	}{
+		{
+			name:       "fresh admin claims are trusted",
+			claims:     &jwtValidation.Claims{UserID: 1, Roles: []string{jwtValidation.RoleAdmin}, IssuedAt: fresh},
+			checker:    &fakeChecker{},
+			wantStatus: http.StatusOK,
+			wantRoles:  []string{jwtValidation.RoleAdmin},
+		},
+		{
+			name:       "fresh student claims are denied without asking SSO",
+			claims:     &jwtValidation.Claims{UserID: 1, Roles: []string{jwtValidation.RoleStudent}, IssuedAt: fresh},
+			checker:    &fakeChecker{admins: map[int64]bool{1: true}},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "stale claims are refreshed: promoted",
+			claims:     &jwtValidation.Claims{UserID: 1, Roles: []string{jwtValidation.RoleStudent}, IssuedAt: stale},
+			checker:    &fakeChecker{admins: map[int64]bool{1: true}},
+			wantStatus: http.StatusOK,
+			wantCalls:  1,
+			wantRoles:  []string{jwtValidation.RoleAdmin},
+		},
+		{
+			name:       "stale claims are refreshed: demoted",
+			claims:     &jwtValidation.Claims{UserID: 1, Roles: []string{jwtValidation.RoleAdmin}, IssuedAt: stale},
+			checker:    &fakeChecker{},
+			wantStatus: http.StatusForbidden,
+			wantCalls:  1,
+		},
+		{
+			name:       "claims without iat are refreshed",
+			claims:     &jwtValidation.Claims{UserID: 1, Roles: []string{jwtValidation.RoleAdmin}},
+			checker:    &fakeChecker{admins: map[int64]bool{1: true}},
+			wantStatus: http.StatusOK,
+			wantCalls:  1,
+			wantRoles:  []string{jwtValidation.RoleAdmin},
+		},
+		{
+			name:       "claims without roles are refreshed",
+			claims:     &jwtValidation.Claims{UserID: 1, IssuedAt: fresh},
+			checker:    &fakeChecker{admins: map[int64]bool{1: true}},
+			wantStatus: http.StatusOK,
+			wantCalls:  1,
+			wantRoles:  []string{jwtValidation.RoleAdmin},
+		},
+		{
+			name:       "SSO down",
+			claims:     &jwtValidation.Claims{UserID: 1, Roles: []string{jwtValidation.RoleAdmin}, IssuedAt: stale},
+			checker:    &fakeChecker{err: ssogrpc.ErrInternal},
+			wantStatus: http.StatusServiceUnavailable,
+			wantCalls:  1,
+		},
+		{
+			name:       "user deleted since login",
+			claims:     &jwtValidation.Claims{UserID: 1, Roles: []string{jwtValidation.RoleAdmin}, IssuedAt: stale},
+			checker:    &fakeChecker{err: ssogrpc.ErrUserNotFound},
+			wantStatus: http.StatusForbidden,
+			wantCalls:  1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var logs bytes.Buffer
+			authz := New(slog.New(slog.NewJSONHandler(&logs, nil)), tt.checker, maxAge)
+
+			var gotRoles []string
+			handler := authz.RequirePermissions(PermDebtsRead)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				claims, _ := MWJwt.ClaimsFromContext(r.Context())
+				gotRoles = claims.Roles
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/debts", nil)
+			req = req.WithContext(MWJwt.WithClaims(req.Context(), tt.claims))
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			require.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, tt.wantCalls, tt.checker.calls)
+			assert.Equal(t, tt.wantRoles, gotRoles)
+
+			if tt.wantStatus != http.StatusOK {
+				assert.Equal(t, problem.ContentType, rec.Header().Get("Content-Type"))
+			}
+
+			if tt.wantStatus == http.StatusForbidden {
+				assert.Contains(t, logs.String(), `"audit":"authz.denied"`)
+			}
+		})
+	}
+}
+
+func TestAuthorizeWithoutAuthentication(t *testing.T) {
+	checker := &fakeChecker{}
+	authz := New(slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil)), checker, maxAge)
+
+	rec := httptest.NewRecorder()
+	_, ok := authz.Authorize(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.False(t, ok)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Zero(t, checker.calls)
+}
+
+func TestRequireRoles(t *testing.T) {
+	authz := New(slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil)), &fakeChecker{}, maxAge)
+	handler := authz.RequireRoles(jwtValidation.RoleStudent, jwtValidation.RoleAdmin)(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+
+	for _, roles := range [][]string{{jwtValidation.RoleStudent}, {jwtValidation.RoleAdmin}, {"librarian"}} {
+		claims := &jwtValidation.Claims{UserID: 1, Roles: roles, IssuedAt: time.Now()}
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req = req.WithContext(MWJwt.WithClaims(req.Context(), claims))
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		want := http.StatusOK
+		if roles[0] == "librarian" {
+			want = http.StatusForbidden
+		}
+
+		assert.Equal(t, want, rec.Code, "roles %v", roles)
+	}
+}
+
+func TestDeny(t *testing.T) {
+	var logs bytes.Buffer
+	authz := New(slog.New(slog.NewJSONHandler(&logs, nil)), &fakeChecker{}, maxAge)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/books", nil)
+	req.RemoteAddr = "192.0.2.1:1234"
+
+	rec := httptest.NewRecorder()
+	authz.Deny(rec, req, &jwtValidation.Claims{UserID: 7, Roles: []string{jwtValidation.RoleStudent}}, "permission catalog:manage")
+
+	require.Equal(t, http.StatusForbidden, rec.Code)
+
+	var p problem.Problem
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &p))
+	assert.Equal(t, problem.CodeForbidden, p.Code)
+
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(logs.Bytes(), &record))
+	assert.Equal(t, "WARN", record["level"])
+	assert.Equal(t, "authz.denied", record["audit"])
+	assert.Equal(t, 7.0, record["user_id"])
+	assert.Equal(t, []any{jwtValidation.RoleStudent}, record["roles"])
+	assert.Equal(t, "permission catalog:manage", record["required"])
+	assert.Equal(t, http.MethodPost, record["method"])
+	assert.Equal(t, "/api/v1/admin/books", record["path"])
+	assert.Equal(t, "192.0.2.1:1234", record["remote_addr"])
+}
+
+func TestCan(t *testing.T) {
+	admin := &jwtValidation.Claims{Roles: []string{jwtValidation.RoleAdmin}}
+	student := &jwtValidation.Claims{Roles: []string{jwtValidation.RoleStudent}}
+	both := &jwtValidation.Claims{Roles: []string{jwtValidation.RoleStudent, jwtValidation.RoleAdmin}}
+	unknown := &jwtValidation.Claims{Roles: []string{"librarian"}}
+	none := &jwtValidation.Claims{}
+
+	for _, perm := range []Permission{PermUsersRead, PermLoansManage, PermCatalogManage, PermDebtsRead} {
+		assert.True(t, Can(admin, perm), "admin %s", perm)
+		assert.True(t, Can(both, perm), "student and admin %s", perm)
+		assert.False(t, Can(student, perm), "student %s", perm)
+		assert.False(t, Can(unknown, perm), "unknown role %s", perm)
+		assert.False(t, Can(none, perm), "no roles %s", perm)
+	}
+
+	assert.False(t, Can(admin, Permission("books:burn")))
+}
+
+var _ RoleChecker = (*fakeChecker)(nil)
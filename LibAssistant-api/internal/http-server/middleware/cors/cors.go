@@ -0,0 +1,122 @@
+package MWCors
+
+import (
+	"LibAssistant_api/internal/config"
+	"LibAssistant_api/internal/lib/api/problem"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+const (
+	allowedMethods = "GET, POST, PUT, PATCH, DELETE"
+	allowedHeaders = "Authorization, Content-Type, Accept, X-CSRF-Token, X-Request-Id"
+	// exposedHeaders are the response headers browser code may read besides the simple ones.
+	exposedHeaders = "Location, Retry-After, Content-Disposition, X-Request-Id"
+)
+
+// CORS lets the allowed browser origins call the API with credentials. Requests
+// from other origins get no CORS headers, so browsers do not let their scripts read
+// the response; such preflights are refused outright.
+type CORS struct {
+	log *slog.Logger
+
+	mu      sync.RWMutex
+	origins map[string]bool
+	maxAge  string
+}
+
+func New(log *slog.Logger, cfg config.CORS) *CORS {
+	c := &CORS{
+		log: log.With(
+			slog.String("component", "middleware/cors"),
+		),
+	}
+
+	c.SetConfig(cfg)
+
+	return c
+}
+
+func (c *CORS) SetConfig(cfg config.CORS) {
+	origins := make(map[string]bool, len(cfg.AllowedOrigins))
+	for _, o := range cfg.AllowedOrigins {
+		origins[normalize(o)] = true
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.origins = origins
+	c.maxAge = strconv.Itoa(int(cfg.MaxAge.Seconds()))
+}
+
+func (c *CORS) allowed(origin string) (bool, string) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.origins[normalize(origin)], c.maxAge
+}
+
+func (c *CORS) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Responses differ by origin, so caches must not share them between origins.
+		w.Header().Add("Vary", "Origin")
+
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			next.ServeHTTP(w, r)
+
+			return
+		}
+
+		preflight := r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+
+		ok, maxAge := c.allowed(origin)
+		if !ok {
+			if preflight {
+				c.log.InfoContext(r.Context(), "cross-origin request refused",
+					slog.String("origin", origin),
+					slog.String("path", r.URL.Path),
+					slog.String("request_id", middleware.GetReqID(r.Context())),
+				)
+
+				problem.Write(w, r, problem.New(http.StatusForbidden, problem.CodeForbidden, "Origin is not allowed"))
+
+				return
+			}
+
+			next.ServeHTTP(w, r)
+
+			return
+		}
+
+		h := w.Header()
+		h.Set("Access-Control-Allow-Origin", origin)
+		h.Set("Access-Control-Allow-Credentials", "true")
+
+		if !preflight {
+			h.Set("Access-Control-Expose-Headers", exposedHeaders)
+
+			next.ServeHTTP(w, r)
+
+			return
+		}
+
+		h.Add("Vary", "Access-Control-Request-Method")
+		h.Add("Vary", "Access-Control-Request-Headers")
+		h.Set("Access-Control-Allow-Methods", allowedMethods)
+		h.Set("Access-Control-Allow-Headers", allowedHeaders)
+		h.Set("Access-Control-Max-Age", maxAge)
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+func normalize(origin string) string {
+	return strings.ToLower(strings.TrimSuffix(origin, "/"))
+}
@@ -0,0 +1,119 @@
+package MWCors
+
+import (
+	"LibAssistant_api/internal/config"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler(t *testing.T) {
+	tests := []struct {
+		name      string
+		method    string
+		origin    string
+		preflight bool
+
+		want        int
+		called      bool
+		allowOrigin string
+		vary        []string
+	}{
+		{
+			name: "preflight from an allowed origin", method: http.MethodOptions, origin: "https://app.example.com", preflight: true,
+			want: http.StatusNoContent, allowOrigin: "https://app.example.com",
+			vary: []string{"Origin", "Access-Control-Request-Method", "Access-Control-Request-Headers"},
+		},
+		{
+			name: "preflight from an allowed origin in another case", method: http.MethodOptions, origin: "https://APP.example.com", preflight: true,
+			want: http.StatusNoContent, allowOrigin: "https://APP.example.com",
+			vary: []string{"Origin", "Access-Control-Request-Method", "Access-Control-Request-Headers"},
+		},
+		{
+			name: "preflight from a refused origin", method: http.MethodOptions, origin: "https://evil.example.com", preflight: true,
+			want: http.StatusForbidden, vary: []string{"Origin"},
+		},
+		{
+			name: "request from an allowed origin", method: http.MethodGet, origin: "https://app.example.com",
+			want: http.StatusOK, called: true, allowOrigin: "https://app.example.com", vary: []string{"Origin"},
+		},
+		{
+			name: "request from a refused origin", method: http.MethodGet, origin: "https://evil.example.com",
+			want: http.StatusOK, called: true, vary: []string{"Origin"},
+		},
+		{
+			name: "same-origin request", method: http.MethodPost,
+			want: http.StatusOK, called: true, vary: []string{"Origin"},
+		},
+		{
+			name: "options without a request method is not a preflight", method: http.MethodOptions, origin: "https://app.example.com",
+			want: http.StatusOK, called: true, allowOrigin: "https://app.example.com", vary: []string{"Origin"},
+		},
+	}
+
+	c := New(slog.New(slog.NewTextHandler(io.Discard, nil)), config.CORS{
+		AllowedOrigins: []string{"https://app.example.com/"},
+		MaxAge:         10 * time.Minute,
+	})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			h := c.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				called = true
+			}))
+
+			r := httptest.NewRequest(tt.method, "/api/v1/auth/login", nil)
+			if tt.origin != "" {
+				r.Header.Set("Origin", tt.origin)
+			}
+			if tt.preflight {
+				r.Header.Set("Access-Control-Request-Method", http.MethodPost)
+				r.Header.Set("Access-Control-Request-Headers", "Content-Type, X-CSRF-Token")
+			}
+
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, r)
+
+			header := rec.Header()
+			assert.Equal(t, tt.want, rec.Code)
+			assert.Equal(t, tt.called, called)
+			assert.Equal(t, tt.allowOrigin, header.Get("Access-Control-Allow-Origin"))
+			assert.Equal(t, tt.vary, header.Values("Vary"))
+
+			if tt.allowOrigin != "" {
+				assert.Equal(t, "true", header.Get("Access-Control-Allow-Credentials"))
+			}
+
+			if tt.want == http.StatusNoContent {
+				assert.Equal(t, allowedMethods, header.Get("Access-Control-Allow-Methods"))
+				assert.Equal(t, allowedHeaders, header.Get("Access-Control-Allow-Headers"))
+				assert.Equal(t, "600", header.Get("Access-Control-Max-Age"))
+			} else {
+				assert.Empty(t, header.Get("Access-Control-Allow-Methods"))
+			}
+
+			if tt.called && tt.allowOrigin != "" {
+				assert.Equal(t, exposedHeaders, header.Get("Access-Control-Expose-Headers"))
+			}
+		})
+	}
+}
+
+func TestSetConfig(t *testing.T) {
+	c := New(slog.New(slog.NewTextHandler(io.Discard, nil)), config.CORS{AllowedOrigins: []string{"https://app.example.com"}})
+
+	c.SetConfig(config.CORS{AllowedOrigins: []string{"https://new.example.com"}, MaxAge: time.Minute})
+
+	ok, maxAge := c.allowed("https://app.example.com")
+	assert.False(t, ok)
+
+	ok, maxAge = c.allowed("https://new.example.com")
+	assert.True(t, ok)
+	assert.Equal(t, "60", maxAge)
+}
@@ -0,0 +1,99 @@
+package MWCsrf
+
+import (
+	MWJwt "LibAssistant_api/internal/http-server/middleware/jwt"
+	"LibAssistant_api/internal/lib/api/problem"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+const (
+	// CookieName is readable by scripts, so a same-site frontend can copy it into HeaderName.
+	CookieName = "csrf_token"
+	HeaderName = "X-CSRF-Token"
+)
+
+// Token returns the CSRF token of the session of accessToken. It is derived from
+// the access token with appSecret, so it cannot be forged or planted by another
+// site and changes with every login.
+func Token(appSecret string, accessToken string) string {
+	mac := hmac.New(sha256.New, []byte(appSecret))
+	mac.Write([]byte("csrf:" + accessToken))
+
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// SetCookie sends the CSRF token of accessToken along with the auth cookie and
+// returns it, for frontends on another site that cannot read the cookie.
+func SetCookie(w http.ResponseWriter, appSecret string, accessToken string) string {
+	token := Token(appSecret, accessToken)
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     CookieName,
+		Value:    token,
+		SameSite: http.SameSiteNoneMode,
+		Path:     "/",
+		Secure:   true,
+	})
+
+	return token
+}
+
+// New requires state-changing requests authenticated with the auth cookie to repeat
+// the session's CSRF token in the X-CSRF-Token header, which another site cannot
+// read or set. Requests with an Authorization header (bearer tokens, API keys) are
+// exempt: browsers never attach it on their own.
+func New(log *slog.Logger, appSecret string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		log := log.With(
+			slog.String("component", "middleware/csrf"),
+		)
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if safe(r.Method) || r.Header.Get("Authorization") != "" {
+				next.ServeHTTP(w, r)
+
+				return
+			}
+
+			cookie, err := r.Cookie(MWJwt.CookieName)
+			if err != nil {
+				next.ServeHTTP(w, r)
+
+				return
+			}
+
+			expected := Token(appSecret, cookie.Value)
+			if !hmac.Equal([]byte(r.Header.Get(HeaderName)), []byte(expected)) {
+				log.WarnContext(r.Context(), "csrf token mismatch",
+					slog.Int64("user_id", MWJwt.UserID(r.Context())),
+					slog.String("method", r.Method),
+					slog.String("path", r.URL.Path),
+					slog.String("origin", r.Header.Get("Origin")),
+					slog.String("remote_addr", r.RemoteAddr),
+					slog.String("request_id", middleware.GetReqID(r.Context())),
+				)
+
+				problem.Write(w, r, problem.New(http.StatusForbidden, problem.CodeCSRFTokenInvalid, "Missing or invalid "+HeaderName+" header"))
+
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func safe(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+		return true
+	default:
+		return false
+	}
+}
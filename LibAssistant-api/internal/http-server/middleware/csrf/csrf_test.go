@@ -0,0 +1,87 @@
+package MWCsrf
+
+import (
+	MWJwt "LibAssistant_api/internal/http-server/middleware/jwt"
+	"LibAssistant_api/internal/lib/api/problem"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	appSecret   = "test-secret"
+	accessToken = "access-token"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		cookie string
+		header string
+		bearer bool
+		want   int
+	}{
+		{name: "matching token", method: http.MethodPost, cookie: accessToken, header: Token(appSecret, accessToken), want: http.StatusOK},
+		{name: "mismatched token", method: http.MethodPost, cookie: accessToken, header: Token(appSecret, "another-session"), want: http.StatusForbidden},
+		{name: "token signed with another secret", method: http.MethodPost, cookie: accessToken, header: Token("other", accessToken), want: http.StatusForbidden},
+		{name: "cookie only", method: http.MethodPost, cookie: accessToken, want: http.StatusForbidden},
+		{name: "cookie only on delete", method: http.MethodDelete, cookie: accessToken, want: http.StatusForbidden},
+		{name: "cookie only on a safe method", method: http.MethodGet, cookie: accessToken, want: http.StatusOK},
+		{name: "bearer is exempt", method: http.MethodPost, cookie: accessToken, bearer: true, want: http.StatusOK},
+		{name: "no cookie", method: http.MethodPost, want: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			h := New(slog.New(slog.NewTextHandler(io.Discard, nil)), appSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				called = true
+			}))
+
+			r := httptest.NewRequest(tt.method, "/api/v1/loans", nil)
+			if tt.cookie != "" {
+				r.AddCookie(&http.Cookie{Name: MWJwt.CookieName, Value: tt.cookie})
+			}
+			if tt.header != "" {
+				r.Header.Set(HeaderName, tt.header)
+			}
+			if tt.bearer {
+				r.Header.Set("Authorization", "Bearer "+accessToken)
+			}
+
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, r)
+
+			assert.Equal(t, tt.want, rec.Code)
+			assert.Equal(t, tt.want == http.StatusOK, called)
+
+			if tt.want == http.StatusForbidden {
+				var p problem.Problem
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &p))
+				assert.Equal(t, problem.CodeCSRFTokenInvalid, p.Code)
+			}
+		})
+	}
+}
+
+func TestSetCookie(t *testing.T) {
+	rec := httptest.NewRecorder()
+
+	token := SetCookie(rec, appSecret, accessToken)
+
+	assert.Equal(t, Token(appSecret, accessToken), token)
+	assert.NotEqual(t, Token(appSecret, "another-session"), token)
+
+	cookies := rec.Result().Cookies()
+	require.Len(t, cookies, 1)
+	assert.Equal(t, CookieName, cookies[0].Name)
+	assert.Equal(t, token, cookies[0].Value)
+	assert.False(t, cookies[0].HttpOnly, "scripts must be able to copy the token into the header")
+}
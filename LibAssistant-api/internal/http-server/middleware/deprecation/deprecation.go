@@ -0,0 +1,42 @@
+package MWDeprecation
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// New marks a legacy route as deprecated since the given time (RFC 9745) and links
+// its successor, a path in which "{id}" is replaced by the request's id parameter.
+// Uses are logged so it is visible which clients still need to migrate.
+func New(log *slog.Logger, since time.Time, successor string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		log := log.With(
+			slog.String("component", "middleware/deprecation"),
+		)
+
+		deprecation := fmt.Sprintf("@%d", since.Unix())
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			path := strings.ReplaceAll(successor, "{id}", chi.URLParam(r, "id"))
+
+			w.Header().Set("Deprecation", deprecation)
+			w.Header().Add("Link", fmt.Sprintf(`<%s>; rel="successor-version"`, path))
+
+			log.InfoContext(r.Context(), "deprecated route used",
+				slog.String("method", r.Method),
+				slog.String("path", r.URL.Path),
+				slog.String("successor", path),
+				slog.String("user_agent", r.UserAgent()),
+				slog.String("request_id", middleware.GetReqID(r.Context())),
+			)
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -1,35 +1,99 @@
 package MWJwt
 
 import (
+	"LibAssistant_api/internal/lib/api/problem"
 	jwtValidation "LibAssistant_api/internal/lib/jwt"
+	"LibAssistant_api/internal/lib/logger/sl"
+	"context"
 	"log/slog"
 	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5/middleware"
 )
 
-func New(log *slog.Logger) func(next http.Handler) http.Handler {
+// CookieName is the cookie the login handler stores the access token in.
+const CookieName = "auth_token"
+
+type claimsKey struct{}
+
+// New authenticates requests with an access token from the "Authorization: Bearer"
+// header or, for browsers, the auth_token cookie. Requests without a valid token get
+// 401; otherwise the verified claims are available through ClaimsFromContext.
+func New(log *slog.Logger, appSecret string) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
-		log = log.With(
+		log := log.With(
 			slog.String("component", "middleware/jwt"),
 		)
 
 		log.Info("jwt validation middleware enabled")
 
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			cookie, err := r.Cookie("auth_token")
-			if err != nil {
-				http.Redirect(w, r, "/login", http.StatusSeeOther)
-				log.Error("cookie not found")
+			log := log.With(slog.String("request_id", middleware.GetReqID(r.Context())))
+
+			token := TokenFromRequest(r)
+			if token == "" {
+				log.InfoContext(r.Context(), "access token is missing")
+
+				unauthorized(w, r, "Access token is required")
+
 				return
 			}
 
-			token := cookie.Value
+			claims, err := jwtValidation.ParseToken(token, appSecret)
+			if err != nil {
+				log.InfoContext(r.Context(), "invalid access token", sl.Err(err))
+
+				unauthorized(w, r, "Invalid or expired access token")
 
-			if err := jwtValidation.ValidateToken(token); err != nil {
-				http.Redirect(w, r, "/login", http.StatusSeeOther)
+				return
 			}
 
-			next.ServeHTTP(w, r)
+			next.ServeHTTP(w, r.WithContext(WithClaims(r.Context(), claims)))
 		})
-		
 	}
 }
+
+// TokenFromRequest returns the access token the request carries, the Authorization
+// header taking precedence over the cookie, or "" if there is none.
+func TokenFromRequest(r *http.Request) string {
+	if h := r.Header.Get("Authorization"); h != "" {
+		scheme, token, ok := strings.Cut(h, " ")
+		if ok && strings.EqualFold(scheme, "Bearer") {
+			return strings.TrimSpace(token)
+		}
+
+		return ""
+	}
+
+	if cookie, err := r.Cookie(CookieName); err == nil {
+		return cookie.Value
+	}
+
+	return ""
+}
+
+func unauthorized(w http.ResponseWriter, r *http.Request, msg string) {
+	w.Header().Set("WWW-Authenticate", `Bearer realm="LibAssistant"`)
+
+	problem.Write(w, r, problem.New(http.StatusUnauthorized, problem.CodeUnauthorized, msg))
+}
+
+func WithClaims(ctx context.Context, claims *jwtValidation.Claims) context.Context {
+	return context.WithValue(ctx, claimsKey{}, claims)
+}
+
+// ClaimsFromContext returns the claims stored by New; ok is false on unprotected routes.
+func ClaimsFromContext(ctx context.Context) (*jwtValidation.Claims, bool) {
+	claims, ok := ctx.Value(claimsKey{}).(*jwtValidation.Claims)
+	return claims, ok
+}
+
+// UserID returns the authenticated user's id, or 0 on unprotected routes.
+func UserID(ctx context.Context) int64 {
+	if claims, ok := ClaimsFromContext(ctx); ok {
+		return claims.UserID
+	}
+
+	return 0
+}
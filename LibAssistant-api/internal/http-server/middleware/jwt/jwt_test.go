@@ -0,0 +1,141 @@
+package MWJwt
+
+import (
+	"LibAssistant_api/internal/lib/api/problem"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const secret = "test-secret"
+
+func token(t *testing.T, uid int64, exp time.Time) string {
+	t.Helper()
+
+	s, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"uid": uid,
+		"exp": exp.Unix(),
+		"iat": time.Now().Unix(),
+	}).SignedString([]byte(secret))
+	require.NoError(t, err)
+
+	return s
+}
+
+func TestNew(t *testing.T) {
+	valid := token(t, 1, time.Now().Add(time.Hour))
+	other := token(t, 2, time.Now().Add(time.Hour))
+	expired := token(t, 1, time.Now().Add(-time.Minute))
+
+	tests := []struct {
+		name          string
+		authorization string
+		cookie        string
+		wantUser      int64
+		wantDetail    string
+	}{
+		{
+			name:          "bearer",
+			authorization: "Bearer " + valid,
+			wantUser:      1,
+		},
+		{
+			name:          "scheme is case-insensitive",
+			authorization: "bearer " + valid,
+			wantUser:      1,
+		},
+		{
+			name:     "cookie",
+			cookie:   valid,
+			wantUser: 1,
+		},
+		{
+			name:          "bearer takes precedence over the cookie",
+			authorization: "Bearer " + other,
+			cookie:        valid,
+			wantUser:      2,
+		},
+		{
+			name:          "an invalid bearer is not rescued by a valid cookie",
+			authorization: "Bearer " + expired,
+			cookie:        valid,
+			wantDetail:    "Invalid or expired access token",
+		},
+		{
+			name:          "non-bearer authorization",
+			authorization: "Basic dXNlcjpwYXNz",
+			cookie:        valid,
+			wantDetail:    "Access token is required",
+		},
+		{
+			name:       "missing",
+			wantDetail: "Access token is required",
+		},
+		{
+			name:       "expired",
+			cookie:     expired,
+			wantDetail: "Invalid or expired access token",
+		},
+		{
+			name:          "garbage",
+			authorization: "Bearer garbage",
+			wantDetail:    "Invalid or expired access token",
+		},
+	}
+
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotUser int64
+			handler := New(log, secret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotUser = UserID(r.Context())
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/me/is-admin", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			if tt.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: CookieName, Value: tt.cookie})
+			}
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if tt.wantDetail == "" {
+				assert.Equal(t, http.StatusOK, rec.Code)
+				assert.Equal(t, tt.wantUser, gotUser)
+
+				return
+			}
+
+			require.Equal(t, http.StatusUnauthorized, rec.Code)
+			assert.Zero(t, gotUser, "the next handler must not run")
+			assert.Equal(t, `Bearer realm="LibAssistant"`, rec.Header().Get("WWW-Authenticate"))
+			assert.Equal(t, problem.ContentType, rec.Header().Get("Content-Type"))
+
+			var p problem.Problem
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &p))
+			assert.Equal(t, problem.CodeUnauthorized, p.Code)
+			assert.Equal(t, tt.wantDetail, p.Detail)
+			assert.Equal(t, "/api/v1/me/is-admin", p.Instance)
+		})
+	}
+}
+
+func TestUserIDWithoutClaims(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	_, ok := ClaimsFromContext(req.Context())
+	assert.False(t, ok)
+	assert.Zero(t, UserID(req.Context()))
+}
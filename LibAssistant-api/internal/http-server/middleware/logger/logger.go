@@ -29,7 +29,7 @@ func New(log *slog.Logger) func(next http.Handler) http.Handler {
 
 			t1 := time.Now()
 			defer func() {
-				entry.Info("request completed", slog.Int("status", ww.Status()), slog.Int("bytes", ww.BytesWritten()), slog.String("duration", time.Since(t1).String()))
+				entry.InfoContext(r.Context(), "request completed", slog.Int("status", ww.Status()), slog.Int("bytes", ww.BytesWritten()), slog.String("duration", time.Since(t1).String()))
 			}()
 
 			next.ServeHTTP(ww, r)
@@ -0,0 +1,47 @@
+package MWMetrics
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+type Observer interface {
+	ObserveHTTP(route, method, code string, seconds float64)
+}
+
+func New(log *slog.Logger, observer Observer) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		log.With(
+			slog.String("component", "middleware/metrics"),
+		).Info("metrics middleware enabled")
+
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+
+			t1 := time.Now()
+			defer func() {
+				// The pattern is only known after routing, so it is read once the handler returned.
+				route := chi.RouteContext(r.Context()).RoutePattern()
+				if route == "" {
+					route = "unmatched"
+				}
+
+				status := ww.Status()
+				if status == 0 {
+					status = http.StatusOK
+				}
+
+				observer.ObserveHTTP(route, r.Method, strconv.Itoa(status), time.Since(t1).Seconds())
+			}()
+
+			next.ServeHTTP(ww, r)
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
@@ -0,0 +1,214 @@
+package MWRateLimit
+
+import (
+	"LibAssistant_api/internal/config"
+	"LibAssistant_api/internal/lib/api/problem"
+	"LibAssistant_api/internal/lib/logger/sl"
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"math"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// maxBody is how much of a request body is read to find the email; larger bodies
+// are only limited by IP and left for the handler to reject.
+const maxBody = 64 << 10
+
+// Limiter applies the per-route policies of config.RateLimit. The config can be
+// swapped at runtime with SetConfig; buckets already in the store are kept.
+type Limiter struct {
+	log   *slog.Logger
+	store Store
+
+	mu      sync.RWMutex
+	cfg     config.RateLimit
+	proxies []netip.Prefix
+}
+
+func New(log *slog.Logger, store Store, cfg config.RateLimit) *Limiter {
+	l := &Limiter{
+		log: log.With(
+			slog.String("component", "middleware/ratelimit"),
+		),
+		store: store,
+	}
+
+	l.SetConfig(cfg)
+
+	return l
+}
+
+// SetConfig swaps the config. Invalid trusted proxies, which config.Validate
+// reports, are skipped.
+func (l *Limiter) SetConfig(cfg config.RateLimit) {
+	proxies := make([]netip.Prefix, 0, len(cfg.TrustedProxies))
+	for _, s := range cfg.TrustedProxies {
+		if p, err := config.ParseProxy(s); err == nil {
+			proxies = append(proxies, p)
+		}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.cfg = cfg
+	l.proxies = proxies
+}
+
+func (l *Limiter) policy(route string) (config.RateLimitPolicy, []netip.Prefix, bool) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	if !l.cfg.Enabled {
+		return config.RateLimitPolicy{}, nil, false
+	}
+
+	p, ok := l.cfg.Policies[route]
+
+	return p, l.proxies, ok
+}
+
+// Route limits the requests to the route named route by client IP and by the
+// email field of the JSON body. A request is let through only if both buckets
+// have a token; otherwise it gets 429 with a Retry-After header. If the store
+// fails, requests are let through rather than locking everybody out.
+func (l *Limiter) Route(route string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			policy, proxies, ok := l.policy(route)
+			if !ok {
+				next.ServeHTTP(w, r)
+
+				return
+			}
+
+			log := l.log.With(
+				slog.String("route", route),
+				slog.String("request_id", middleware.GetReqID(r.Context())),
+			)
+
+			ip := clientIP(r, proxies)
+
+			retryAfter, limited := l.take(r, log, route+":ip:"+ip, policy.IP)
+			if !limited && policy.Email.Requests > 0 {
+				if email := emailFromBody(r); email != "" {
+					retryAfter, limited = l.take(r, log, route+":email:"+email, policy.Email)
+				}
+			}
+
+			if limited {
+				log.WarnContext(r.Context(), "rate limit exceeded",
+					slog.String("remote_addr", ip),
+					slog.Duration("retry_after", retryAfter),
+				)
+
+				seconds := int(math.Ceil(retryAfter.Seconds()))
+				if seconds < 1 {
+					seconds = 1
+				}
+
+				w.Header().Set("Retry-After", strconv.Itoa(seconds))
+				problem.Write(w, r, problem.New(http.StatusTooManyRequests, problem.CodeRateLimited, "Too many requests, try again later"))
+
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// take reports whether the bucket key is empty and, if so, when to retry.
+func (l *Limiter) take(r *http.Request, log *slog.Logger, key string, limit config.Limit) (time.Duration, bool) {
+	if limit.Requests <= 0 {
+		return 0, false
+	}
+
+	ok, retryAfter, err := l.store.Take(r.Context(), key, limit)
+	if err != nil {
+		log.ErrorContext(r.Context(), "rate limit store failed", sl.Err(err))
+
+		return 0, false
+	}
+
+	return retryAfter, !ok
+}
+
+// clientIP is the peer address, or for a peer among proxies the nearest
+// X-Forwarded-For hop that is not a trusted proxy. Hops further left are set by
+// the client and could be forged to get a fresh bucket.
+func clientIP(r *http.Request, proxies []netip.Prefix) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+
+	peer, err := netip.ParseAddr(host)
+	if err != nil || !trusted(peer, proxies) {
+		return host
+	}
+
+	hops := strings.Split(strings.Join(r.Header.Values("X-Forwarded-For"), ","), ",")
+
+	for i := len(hops) - 1; i >= 0; i-- {
+		addr, err := netip.ParseAddr(strings.TrimSpace(hops[i]))
+		if err != nil {
+			break
+		}
+
+		host = addr.Unmap().String()
+
+		if !trusted(addr, proxies) {
+			break
+		}
+	}
+
+	return host
+}
+
+func trusted(addr netip.Addr, proxies []netip.Prefix) bool {
+	addr = addr.Unmap()
+
+	for _, p := range proxies {
+		if p.Contains(addr) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// emailFromBody returns the normalized email field of a JSON body and puts the
+// body back for the handler.
+func emailFromBody(r *http.Request) string {
+	if r.Body == nil {
+		return ""
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxBody+1))
+	r.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), r.Body))
+
+	if err != nil || len(body) > maxBody {
+		return ""
+	}
+
+	var req struct {
+		Email string `json:"email"`
+	}
+
+	if err := json.Unmarshal(body, &req); err != nil {
+		return ""
+	}
+
+	return strings.ToLower(strings.TrimSpace(req.Email))
+}
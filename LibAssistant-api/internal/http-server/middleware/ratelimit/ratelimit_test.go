@@ -0,0 +1,172 @@
+package MWRateLimit
+
+import (
+	"LibAssistant_api/internal/config"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLimiter(policy config.RateLimitPolicy, proxies ...string) (http.Handler, *[]string) {
+	store, _ := newTestStore()
+
+	l := New(slog.New(slog.NewTextHandler(io.Discard, nil)), store, config.RateLimit{
+		Enabled:        true,
+		Policies:       map[string]config.RateLimitPolicy{"login": policy},
+		TrustedProxies: proxies,
+	})
+
+	var bodies []string
+
+	return l.Route("login")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+	})), &bodies
+}
+
+func post(h http.Handler, remoteAddr string, body string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(http.MethodPost, "/api/v1/auth/login", strings.NewReader(body))
+	r.RemoteAddr = remoteAddr
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, r)
+
+	return rec
+}
+
+func TestRouteRetryAfter(t *testing.T) {
+	tests := []struct {
+		name  string
+		limit config.Limit
+		want  string
+	}{
+		{name: "whole seconds", limit: config.Limit{Requests: 1, Per: time.Minute}, want: "60"},
+		{name: "rounded up", limit: config.Limit{Requests: 2, Per: 3 * time.Second}, want: "2"},
+		{name: "at least one second", limit: config.Limit{Requests: 10, Per: time.Second}, want: "1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, _ := newTestLimiter(config.RateLimitPolicy{IP: tt.limit})
+
+			for range tt.limit.Requests {
+				require.Equal(t, http.StatusOK, post(h, "192.0.2.1:1234", "{}").Code)
+			}
+
+			rec := post(h, "192.0.2.1:1234", "{}")
+
+			assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+			assert.Equal(t, tt.want, rec.Header().Get("Retry-After"))
+		})
+	}
+}
+
+func TestRouteEmailBucket(t *testing.T) {
+	h, bodies := newTestLimiter(config.RateLimitPolicy{
+		IP:    config.Limit{Requests: 10, Per: time.Minute},
+		Email: config.Limit{Requests: 1, Per: time.Minute},
+	})
+
+	first := `{"email":"Reader@Example.com","password":"secret"}`
+	require.Equal(t, http.StatusOK, post(h, "192.0.2.1:1", first).Code)
+
+	// The handler still reads the whole body after the email was taken from it.
+	assert.Equal(t, []string{first}, *bodies)
+
+	// Another IP with the same address, normalized, shares the email bucket.
+	assert.Equal(t, http.StatusTooManyRequests, post(h, "192.0.2.2:1", `{"email":" reader@example.com "}`).Code)
+
+	assert.Equal(t, http.StatusOK, post(h, "192.0.2.2:1", `{"email":"other@example.com"}`).Code)
+	assert.Equal(t, http.StatusOK, post(h, "192.0.2.2:1", `not json`).Code)
+	assert.Equal(t, []string{first, `{"email":"other@example.com"}`, `not json`}, *bodies)
+}
+
+func TestRouteLargeBodyIsKeptWhole(t *testing.T) {
+	h, bodies := newTestLimiter(config.RateLimitPolicy{
+		IP:    config.Limit{Requests: 10, Per: time.Minute},
+		Email: config.Limit{Requests: 1, Per: time.Minute},
+	})
+
+	body := `{"email":"reader@example.com","pad":"` + strings.Repeat("x", maxBody) + `"}`
+
+	require.Equal(t, http.StatusOK, post(h, "192.0.2.1:1", body).Code)
+	// Too large to look at, so the email bucket is not used.
+	require.Equal(t, http.StatusOK, post(h, "192.0.2.1:1", body).Code)
+
+	require.Len(t, *bodies, 2)
+	assert.Equal(t, body, (*bodies)[0])
+}
+
+func TestClientIP(t *testing.T) {
+	proxies := []netip.Prefix{
+		netip.MustParsePrefix("10.0.0.0/8"),
+		netip.MustParsePrefix("2001:db8::1/128"),
+	}
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		forwarded  []string
+		proxies    []netip.Prefix
+		want       string
+	}{
+		{name: "no proxies", remoteAddr: "192.0.2.1:1234", want: "192.0.2.1"},
+		{name: "forwarded ignored without proxies", remoteAddr: "10.0.0.1:1234", forwarded: []string{"192.0.2.7"}, want: "10.0.0.1"},
+		{name: "untrusted peer", remoteAddr: "192.0.2.1:1234", forwarded: []string{"198.51.100.1"}, proxies: proxies, want: "192.0.2.1"},
+		{name: "trusted peer", remoteAddr: "10.0.0.1:1234", forwarded: []string{"192.0.2.7"}, proxies: proxies, want: "192.0.2.7"},
+		{
+			name: "forged hops left of the client", remoteAddr: "10.0.0.1:1234",
+			forwarded: []string{"203.0.113.9, 192.0.2.7"}, proxies: proxies, want: "192.0.2.7",
+		},
+		{
+			name: "chained proxies", remoteAddr: "10.0.0.1:1234",
+			forwarded: []string{"192.0.2.7, 10.1.1.1", "10.2.2.2"}, proxies: proxies, want: "192.0.2.7",
+		},
+		{name: "only proxies", remoteAddr: "10.0.0.1:1234", forwarded: []string{"10.3.3.3"}, proxies: proxies, want: "10.3.3.3"},
+		{name: "garbage hop", remoteAddr: "10.0.0.1:1234", forwarded: []string{"unknown"}, proxies: proxies, want: "10.0.0.1"},
+		{name: "no header", remoteAddr: "10.0.0.1:1234", proxies: proxies, want: "10.0.0.1"},
+		{name: "ipv6 proxy", remoteAddr: "[2001:db8::1]:443", forwarded: []string{"2001:db8::42"}, proxies: proxies, want: "2001:db8::42"},
+		{name: "mapped ipv4 hop", remoteAddr: "10.0.0.1:1234", forwarded: []string{"::ffff:192.0.2.7"}, proxies: proxies, want: "192.0.2.7"},
+		{name: "remote addr without port", remoteAddr: "192.0.2.1", want: "192.0.2.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/", nil)
+			r.RemoteAddr = tt.remoteAddr
+
+			for _, v := range tt.forwarded {
+				r.Header.Add("X-Forwarded-For", v)
+			}
+
+			assert.Equal(t, tt.want, clientIP(r, tt.proxies))
+		})
+	}
+}
+
+func TestRouteTrustedProxy(t *testing.T) {
+	h, _ := newTestLimiter(config.RateLimitPolicy{IP: config.Limit{Requests: 1, Per: time.Minute}}, "10.0.0.0/8")
+
+	forwarded := func(client string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodPost, "/api/v1/auth/login", strings.NewReader("{}"))
+		r.RemoteAddr = "10.0.0.1:1234"
+		r.Header.Set("X-Forwarded-For", client)
+
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, r)
+
+		return rec
+	}
+
+	assert.Equal(t, http.StatusOK, forwarded("192.0.2.1").Code)
+	assert.Equal(t, http.StatusOK, forwarded("192.0.2.2").Code, "clients behind the proxy get their own buckets")
+	assert.Equal(t, http.StatusTooManyRequests, forwarded("192.0.2.1").Code)
+}
@@ -0,0 +1,115 @@
+package MWRateLimit
+
+import (
+	"LibAssistant_api/internal/config"
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// Store keeps token buckets. The in-memory store is enough for a single gateway;
+// replicas need a shared implementation (e.g. Redis) so a client cannot multiply
+// its allowance by spreading requests over them.
+type Store interface {
+	// Take removes a token from the bucket key, which holds up to limit.Requests
+	// tokens refilled evenly over limit.Per. If the bucket is empty it returns false
+	// and how long until the next token is available.
+	Take(ctx context.Context, key string, limit config.Limit) (ok bool, retryAfter time.Duration, err error)
+}
+
+type bucket struct {
+	tokens   float64
+	updated  time.Time
+	capacity float64
+	perToken time.Duration
+}
+
+// level is the number of tokens in b at now.
+func (b *bucket) level(now time.Time) float64 {
+	return math.Min(b.capacity, b.tokens+float64(now.Sub(b.updated))/float64(b.perToken))
+}
+
+type MemoryStore struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	now     func() time.Time
+
+	stop chan struct{}
+	once sync.Once
+}
+
+// NewMemoryStore returns a store that drops refilled buckets every sweepInterval;
+// a new bucket starts full, so forgetting them does not change any limit.
+func NewMemoryStore(sweepInterval time.Duration) *MemoryStore {
+	s := &MemoryStore{
+		buckets: make(map[string]*bucket),
+		now:     time.Now,
+		stop:    make(chan struct{}),
+	}
+
+	go s.sweep(sweepInterval)
+
+	return s
+}
+
+func (s *MemoryStore) Take(_ context.Context, key string, limit config.Limit) (bool, time.Duration, error) {
+	capacity := float64(limit.Requests)
+	perToken := limit.Per / time.Duration(limit.Requests)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: capacity, updated: now}
+		s.buckets[key] = b
+	}
+
+	// A reloaded limit applies from now on; level caps the tokens at the new capacity.
+	b.capacity, b.perToken = capacity, perToken
+	b.tokens = b.level(now)
+	b.updated = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+
+		return true, 0, nil
+	}
+
+	return false, time.Duration((1 - b.tokens) * float64(perToken)), nil
+}
+
+// Stop stops sweeping; the store can still be used.
+func (s *MemoryStore) Stop() {
+	s.once.Do(func() { close(s.stop) })
+}
+
+func (s *MemoryStore) sweep(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+			s.evict()
+		}
+	}
+}
+
+func (s *MemoryStore) evict() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+
+	for key, b := range s.buckets {
+		if b.level(now) >= b.capacity {
+			delete(s.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package MWRateLimit
+
+import (
+	"LibAssistant_api/internal/config"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// clock is a settable time source for MemoryStore.now.
+type clock struct {
+	t time.Time
+}
+
+func (c *clock) now() time.Time { return c.t }
+
+func (c *clock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func newTestStore() (*MemoryStore, *clock) {
+	c := &clock{t: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+
+	return &MemoryStore{buckets: make(map[string]*bucket), now: c.now, stop: make(chan struct{})}, c
+}
+
+func TestMemoryStoreTake(t *testing.T) {
+	threePerThreeSeconds := config.Limit{Requests: 3, Per: 3 * time.Second}
+
+	type step struct {
+		advance    time.Duration
+		limit      config.Limit
+		ok         bool
+		retryAfter time.Duration
+	}
+
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{
+			name: "burst up to capacity",
+			steps: []step{
+				{limit: threePerThreeSeconds, ok: true},
+				{limit: threePerThreeSeconds, ok: true},
+				{limit: threePerThreeSeconds, ok: true},
+				{limit: threePerThreeSeconds, retryAfter: time.Second},
+			},
+		},
+		{
+			name: "partial refill shortens the wait",
+			steps: []step{
+				{limit: threePerThreeSeconds, ok: true},
+				{limit: threePerThreeSeconds, ok: true},
+				{limit: threePerThreeSeconds, ok: true},
+				{advance: 400 * time.Millisecond, limit: threePerThreeSeconds, retryAfter: 600 * time.Millisecond},
+			},
+		},
+		{
+			name: "refill gives one token per period share",
+			steps: []step{
+				{limit: threePerThreeSeconds, ok: true},
+				{limit: threePerThreeSeconds, ok: true},
+				{limit: threePerThreeSeconds, ok: true},
+				{advance: time.Second, limit: threePerThreeSeconds, ok: true},
+				{limit: threePerThreeSeconds, retryAfter: time.Second},
+			},
+		},
+		{
+			name: "refill stops at capacity",
+			steps: []step{
+				{limit: threePerThreeSeconds, ok: true},
+				{advance: time.Hour, limit: threePerThreeSeconds, ok: true},
+				{limit: threePerThreeSeconds, ok: true},
+				{limit: threePerThreeSeconds, ok: true},
+				{limit: threePerThreeSeconds, retryAfter: time.Second},
+			},
+		},
+		{
+			name: "reload lowering capacity caps the tokens",
+			steps: []step{
+				{limit: config.Limit{Requests: 10, Per: 10 * time.Second}, ok: true},
+				{limit: config.Limit{Requests: 2, Per: 10 * time.Second}, ok: true},
+				{limit: config.Limit{Requests: 2, Per: 10 * time.Second}, ok: true},
+				{limit: config.Limit{Requests: 2, Per: 10 * time.Second}, retryAfter: 5 * time.Second},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, c := newTestStore()
+
+			for i, st := range tt.steps {
+				c.advance(st.advance)
+
+				ok, retryAfter, err := s.Take(context.Background(), "key", st.limit)
+				require.NoError(t, err)
+
+				assert.Equal(t, st.ok, ok, "step %d", i)
+				assert.Equal(t, st.retryAfter, retryAfter, "step %d", i)
+			}
+		})
+	}
+}
+
+func TestMemoryStoreBucketsAreSeparate(t *testing.T) {
+	s, _ := newTestStore()
+	limit := config.Limit{Requests: 1, Per: time.Minute}
+
+	ok, _, _ := s.Take(context.Background(), "a", limit)
+	assert.True(t, ok)
+
+	ok, _, _ = s.Take(context.Background(), "b", limit)
+	assert.True(t, ok)
+
+	ok, _, _ = s.Take(context.Background(), "a", limit)
+	assert.False(t, ok)
+}
+
+func TestMemoryStoreEvict(t *testing.T) {
+	s, c := newTestStore()
+	limit := config.Limit{Requests: 2, Per: 2 * time.Minute}
+
+	_, _, _ = s.Take(context.Background(), "refilled", limit)
+	c.advance(30 * time.Second)
+	_, _, _ = s.Take(context.Background(), "draining", limit)
+	_, _, _ = s.Take(context.Background(), "draining", limit)
+
+	c.advance(30 * time.Second)
+	s.evict()
+
+	assert.NotContains(t, s.buckets, "refilled")
+	assert.Contains(t, s.buckets, "draining")
+
+	// An evicted bucket starts full again, as it would have been.
+	ok, _, _ := s.Take(context.Background(), "refilled", limit)
+	assert.True(t, ok)
+	ok, _, _ = s.Take(context.Background(), "refilled", limit)
+	assert.True(t, ok)
+
+	c.advance(time.Hour)
+	s.evict()
+
+	assert.Empty(t, s.buckets)
+}
@@ -0,0 +1,218 @@
+package export
+
+import (
+	"LibAssistant_api/internal/lib/api/problem"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/xuri/excelize/v2"
+)
+
+type Format string
+
+const (
+	JSON Format = "json"
+	CSV  Format = "csv"
+	XLSX Format = "xlsx"
+)
+
+const (
+	ContentTypeCSV  = "text/csv"
+	ContentTypeXLSX = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+)
+
+var ErrNotAcceptable = errors.New("none of the accepted formats is supported")
+
+func init() {
+	problem.Register(ErrNotAcceptable, http.StatusNotAcceptable, problem.CodeNotAcceptable, "Supported formats are json, csv and xlsx")
+}
+
+var contentTypes = map[string]Format{
+	"application/json": JSON,
+	"application/*":    JSON,
+	"*/*":              JSON,
+	ContentTypeCSV:     CSV,
+	"text/*":           CSV,
+	ContentTypeXLSX:    XLSX,
+}
+
+// Negotiate picks the response format. The format query parameter or, on routes
+// behind middleware.URLFormat, an extension such as /debts.csv wins, so plain
+// download links work in a browser; otherwise the Accept entry with the highest
+// quality is used, and JSON if there is no Accept header.
+func Negotiate(r *http.Request) (Format, error) {
+	f := r.URL.Query().Get("format")
+	if f == "" {
+		f, _ = r.Context().Value(middleware.URLFormatCtxKey).(string)
+	}
+
+	if f != "" {
+		switch Format(strings.ToLower(f)) {
+		case JSON, CSV, XLSX:
+			return Format(strings.ToLower(f)), nil
+		default:
+			return "", fmt.Errorf("%w: format=%s", ErrNotAcceptable, f)
+		}
+	}
+
+	accept := r.Header.Get("Accept")
+	if accept == "" {
+		return JSON, nil
+	}
+
+	best, bestQ := Format(""), 0.0
+
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+
+		format, ok := contentTypes[mediaType]
+		if !ok {
+			continue
+		}
+
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(v, 64); err != nil {
+				continue
+			}
+		}
+
+		if q > bestQ {
+			best, bestQ = format, q
+		}
+	}
+
+	if best == "" {
+		return "", fmt.Errorf("%w: %s", ErrNotAcceptable, accept)
+	}
+
+	return best, nil
+}
+
+// Table is tabular data for the file formats; cells are strings, numbers or times.
+type Table struct {
+	Header []string
+	Rows   [][]any
+}
+
+// WriteCSV sends t as an attachment named filename. A UTF-8 byte order mark is
+// written first, so spreadsheet programs do not garble non-Latin names.
+func WriteCSV(w http.ResponseWriter, filename string, t Table) error {
+	w.Header().Set("Content-Type", ContentTypeCSV+"; charset=utf-8")
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+
+	if _, err := w.Write([]byte("\ufeff")); err != nil {
+		return err
+	}
+
+	cw := csv.NewWriter(w)
+
+	if err := cw.Write(t.Header); err != nil {
+		return err
+	}
+
+	record := make([]string, len(t.Header))
+	for _, row := range t.Rows {
+		for i, cell := range row {
+			record[i] = csvCell(cell)
+		}
+
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+
+	return cw.Error()
+}
+
+func csvCell(v any) string {
+	switch v := v.(type) {
+	case time.Time:
+		if v.IsZero() {
+			return ""
+		}
+
+		return v.Format(time.DateOnly)
+	case string:
+		// Keeps spreadsheet programs from evaluating user-provided text as a formula.
+		if v != "" && strings.ContainsRune("=+-@", rune(v[0])) {
+			return "'" + v
+		}
+
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// WriteXLSX sends t as a single-sheet workbook named filename, with a bold header
+// row and dates formatted as dates.
+func WriteXLSX(w http.ResponseWriter, filename string, sheet string, t Table) error {
+	const op = "lib.Export.WriteXLSX"
+
+	f := excelize.NewFile()
+	defer f.Close()
+
+	if err := f.SetSheetName(f.GetSheetName(0), sheet); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	bold, err := f.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	dateFormat := "yyyy-mm-dd"
+	date, err := f.NewStyle(&excelize.Style{CustomNumFmt: &dateFormat})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	for col, name := range t.Header {
+		cell, _ := excelize.CoordinatesToCellName(col+1, 1)
+		if err := f.SetCellValue(sheet, cell, name); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		_ = f.SetCellStyle(sheet, cell, cell, bold)
+	}
+
+	for i, row := range t.Rows {
+		for col, v := range row {
+			cell, _ := excelize.CoordinatesToCellName(col+1, i+2)
+
+			if tm, ok := v.(time.Time); ok {
+				if tm.IsZero() {
+					continue
+				}
+
+				_ = f.SetCellStyle(sheet, cell, cell, date)
+			}
+
+			if err := f.SetCellValue(sheet, cell, v); err != nil {
+				return fmt.Errorf("%s: %w", op, err)
+			}
+		}
+	}
+
+	w.Header().Set("Content-Type", ContentTypeXLSX)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+
+	if err := f.Write(w); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,112 @@
+package export
+
+import (
+	"context"
+	"encoding/csv"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		extension string
+		accept    string
+		want      Format
+		wantErr   bool
+	}{
+		{name: "no accept header", want: JSON},
+		{name: "json", accept: "application/json", want: JSON},
+		{name: "any", accept: "*/*", want: JSON},
+		{name: "csv", accept: "text/csv", want: CSV},
+		{name: "text wildcard", accept: "text/*", want: CSV},
+		{name: "xlsx", accept: ContentTypeXLSX, want: XLSX},
+		{name: "highest quality wins", accept: "application/json;q=0.5, text/csv;q=0.9", want: CSV},
+		{name: "first of equal quality wins", accept: "text/csv, application/json", want: CSV},
+		{name: "unknown types skipped", accept: "image/png, text/csv;q=0.1", want: CSV},
+		{name: "bad quality skipped", accept: "text/csv;q=abc, application/json;q=0.2", want: JSON},
+		{name: "nothing acceptable", accept: "image/png", wantErr: true},
+		{name: "query parameter", query: "?format=xlsx", accept: "application/json", want: XLSX},
+		{name: "query parameter is case-insensitive", query: "?format=CSV", want: CSV},
+		{name: "unknown query parameter", query: "?format=pdf", wantErr: true},
+		{name: "extension", extension: "csv", accept: "application/json", want: CSV},
+		{name: "query parameter beats extension", query: "?format=json", extension: "csv", want: JSON},
+		{name: "unknown extension", extension: "pdf", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/debts"+tt.query, nil)
+			if tt.accept != "" {
+				r.Header.Set("Accept", tt.accept)
+			}
+
+			if tt.extension != "" {
+				r = r.WithContext(context.WithValue(r.Context(), middleware.URLFormatCtxKey, tt.extension))
+			}
+
+			got, err := Negotiate(r)
+
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrNotAcceptable)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCSVCell(t *testing.T) {
+	tests := []struct {
+		name string
+		in   any
+		want string
+	}{
+		{name: "plain text", in: "Ivanov", want: "Ivanov"},
+		{name: "empty text", in: "", want: ""},
+		{name: "formula", in: "=HYPERLINK(\"x\")", want: "'=HYPERLINK(\"x\")"},
+		{name: "plus", in: "+1", want: "'+1"},
+		{name: "minus", in: "-1", want: "'-1"},
+		{name: "at", in: "@SUM(A1)", want: "'@SUM(A1)"},
+		{name: "sign inside text", in: "a=b", want: "a=b"},
+		{name: "negative number is not text", in: int32(-5), want: "-5"},
+		{name: "date", in: time.Date(2026, 3, 4, 15, 0, 0, 0, time.UTC), want: "2026-03-04"},
+		{name: "zero time", in: time.Time{}, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, csvCell(tt.in))
+		})
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	rec := httptest.NewRecorder()
+
+	err := WriteCSV(rec, "debts.csv", Table{
+		Header: []string{"Student", "Fine"},
+		Rows:   [][]any{{"Иванов", int32(100)}, {"=1+1", int32(0)}},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "text/csv; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename=debts.csv`, rec.Header().Get("Content-Disposition"))
+
+	body := rec.Body.String()
+	require.True(t, strings.HasPrefix(body, "\ufeff"), "the body starts with a byte order mark")
+
+	records, err := csv.NewReader(strings.NewReader(strings.TrimPrefix(body, "\ufeff"))).ReadAll()
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"Student", "Fine"}, {"Иванов", "100"}, {"'=1+1", "0"}}, records)
+}
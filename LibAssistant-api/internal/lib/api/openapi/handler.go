@@ -0,0 +1,75 @@
+package openapi
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"path"
+)
+
+//go:generate go run -C swagger-ui fetch.go
+
+//go:embed docs.html
+var docsHTML string
+
+// swaggerUI holds the vendored Swagger UI; see swagger-ui/README.md.
+//
+//go:embed swagger-ui/swagger-ui.css swagger-ui/swagger-ui-bundle.js
+var swaggerUI embed.FS
+
+// assetTypes are the files of swaggerUI that Assets serves.
+var assetTypes = map[string]string{
+	"swagger-ui.css":       "text/css; charset=utf-8",
+	"swagger-ui-bundle.js": "text/javascript; charset=utf-8",
+}
+
+var docsTemplate = template.Must(template.New("docs").Parse(docsHTML))
+
+// Handler serves doc as JSON. The document is encoded once, up front.
+func Handler(doc *Document) http.HandlerFunc {
+	body, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		panic("openapi: cannot encode document: " + err.Error())
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(body)
+	}
+}
+
+// Docs serves Swagger UI for the document at specURL, loading the UI from assetsURL,
+// where Assets is mounted. Nothing is loaded from other hosts.
+func Docs(specURL string, assetsURL string) http.HandlerFunc {
+	var page bytes.Buffer
+	if err := docsTemplate.Execute(&page, struct{ SpecURL, AssetsURL string }{specURL, assetsURL}); err != nil {
+		panic("openapi: cannot render docs page: " + err.Error())
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write(page.Bytes())
+	}
+}
+
+// Assets serves the vendored Swagger UI files by the last element of the path.
+func Assets() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		name := path.Base(r.URL.Path)
+
+		contentType, ok := assetTypes[name]
+		if !ok {
+			http.NotFound(w, r)
+
+			return
+		}
+
+		body, _ := swaggerUI.ReadFile("swagger-ui/" + name)
+
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("Cache-Control", "public, max-age=86400")
+		_, _ = w.Write(body)
+	}
+}
@@ -0,0 +1,501 @@
+// Package openapi builds an OpenAPI 3 document from a table of endpoints, deriving
+// the schemas of request and response bodies from the handlers' Go types.
+package openapi
+
+import (
+	"LibAssistant_api/internal/lib/api/problem"
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const Version = "3.0.3"
+
+// Endpoint describes one route. Path is the chi pattern, e.g. /api/v1/books/{id};
+// its parameters are documented as positive integer ids.
+type Endpoint struct {
+	Method      string
+	Path        string
+	Tag         string
+	Summary     string
+	Description string
+
+	// Auth routes need an access token, as a bearer token or the auth cookie.
+	Auth bool
+	// RateLimited routes may answer 429.
+	RateLimited bool
+	Deprecated  bool
+
+	// Request is the JSON body, nil if the route takes none.
+	Request any
+	Query   []Parameter
+
+	// Status is the success status, 200 if zero. Response is its JSON body, nil if
+	// it has none; Formats are further media types the route can answer with.
+	Status   int
+	Response any
+	Formats  []string
+
+	// Errors are statuses the route answers with besides those implied above.
+	Errors []int
+}
+
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+// QueryParam returns an optional query parameter of type typ, e.g. "integer".
+func QueryParam(name string, typ string, description string, enum ...string) Parameter {
+	return Parameter{Name: name, In: "query", Description: description, Schema: &Schema{Type: typ, Enum: enum}}
+}
+
+type Document struct {
+	OpenAPI    string              `json:"openapi"`
+	Info       Info                `json:"info"`
+	Tags       []Tag               `json:"tags,omitempty"`
+	Paths      map[string]PathItem `json:"paths"`
+	Components Components          `json:"components"`
+}
+
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+type Tag struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// PathItem maps lowercase HTTP methods to operations.
+type PathItem map[string]*Operation
+
+type Operation struct {
+	Tags        []string              `json:"tags,omitempty"`
+	Summary     string                `json:"summary,omitempty"`
+	Description string                `json:"description,omitempty"`
+	OperationID string                `json:"operationId"`
+	Deprecated  bool                  `json:"deprecated,omitempty"`
+	Parameters  []Parameter           `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]Response   `json:"responses"`
+	Security    []map[string][]string `json:"security,omitempty"`
+}
+
+type RequestBody struct {
+	Required bool                 `json:"required,omitempty"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+type Response struct {
+	Description string               `json:"description"`
+	Headers     map[string]Header    `json:"headers,omitempty"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+type Header struct {
+	Description string  `json:"description,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+type Components struct {
+	Schemas         map[string]*Schema        `json:"schemas"`
+	SecuritySchemes map[string]SecurityScheme `json:"securitySchemes"`
+}
+
+type SecurityScheme struct {
+	Type         string `json:"type"`
+	Scheme       string `json:"scheme,omitempty"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+	In           string `json:"in,omitempty"`
+	Name         string `json:"name,omitempty"`
+	Description  string `json:"description,omitempty"`
+}
+
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	ExclusiveMinimum     bool               `json:"exclusiveMinimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+}
+
+const (
+	bearerAuth = "bearerAuth"
+	cookieAuth = "cookieAuth"
+)
+
+var pathParam = regexp.MustCompile(`\{([^}]+)\}`)
+
+// Build returns the document for endpoints. It panics on an endpoint listed twice,
+// which is a mistake in the table rather than something to handle at runtime.
+func Build(info Info, tags []Tag, endpoints []Endpoint) *Document {
+	doc := &Document{
+		OpenAPI: Version,
+		Info:    info,
+		Tags:    tags,
+		Paths:   make(map[string]PathItem),
+		Components: Components{
+			Schemas: make(map[string]*Schema),
+			SecuritySchemes: map[string]SecurityScheme{
+				bearerAuth: {
+					Type:         "http",
+					Scheme:       "bearer",
+					BearerFormat: "JWT",
+					Description:  "Access token from the login response.",
+				},
+				cookieAuth: {
+					Type: "apiKey",
+					In:   "cookie",
+					Name: "auth_token",
+					Description: "Access token cookie set by login. State-changing requests must " +
+						"repeat the session's CSRF token in the X-CSRF-Token header.",
+				},
+			},
+		},
+	}
+
+	g := &generator{schemas: doc.Components.Schemas}
+	problemSchema := g.schema(reflect.TypeOf(problem.Problem{}))
+
+	for _, e := range endpoints {
+		item, ok := doc.Paths[e.Path]
+		if !ok {
+			item = make(PathItem)
+			doc.Paths[e.Path] = item
+		}
+
+		method := strings.ToLower(e.Method)
+		if _, dup := item[method]; dup {
+			panic(fmt.Sprintf("openapi: %s %s is listed twice", e.Method, e.Path))
+		}
+
+		item[method] = g.operation(e, problemSchema)
+	}
+
+	return doc
+}
+
+// Routes returns "METHOD path" for every operation in d, sorted.
+func (d *Document) Routes() []string {
+	var routes []string
+
+	for path, item := range d.Paths {
+		for method := range item {
+			routes = append(routes, strings.ToUpper(method)+" "+path)
+		}
+	}
+
+	sort.Strings(routes)
+
+	return routes
+}
+
+type generator struct {
+	schemas map[string]*Schema
+}
+
+func (g *generator) operation(e Endpoint, problemSchema *Schema) *Operation {
+	op := &Operation{
+		Summary:     e.Summary,
+		Description: e.Description,
+		OperationID: operationID(e.Method, e.Path),
+		Deprecated:  e.Deprecated,
+		Responses:   make(map[string]Response),
+	}
+
+	if e.Tag != "" {
+		op.Tags = []string{e.Tag}
+	}
+
+	for _, m := range pathParam.FindAllStringSubmatch(e.Path, -1) {
+		// {id} parameters are parsed with request.PathID; any others are names.
+		schema := &Schema{Type: "string"}
+		if m[1] == "id" {
+			schema = &Schema{Type: "integer", Format: "int64", Minimum: float(1)}
+		}
+
+		op.Parameters = append(op.Parameters, Parameter{
+			Name:     m[1],
+			In:       "path",
+			Required: true,
+			Schema:   schema,
+		})
+	}
+
+	op.Parameters = append(op.Parameters, e.Query...)
+
+	invalid := len(op.Parameters) > 0
+
+	if e.Request != nil {
+		t := reflect.TypeOf(e.Request)
+		schema := g.schema(t)
+
+		op.RequestBody = &RequestBody{
+			Required: len(g.resolve(schema).Required) > 0,
+			Content:  map[string]MediaType{"application/json": {Schema: schema}},
+		}
+
+		invalid = true
+	}
+
+	status := e.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+
+	success := Response{Description: http.StatusText(status)}
+	if e.Response != nil || len(e.Formats) > 0 {
+		success.Content = make(map[string]MediaType)
+	}
+
+	if e.Response != nil {
+		success.Content["application/json"] = MediaType{Schema: g.schema(reflect.TypeOf(e.Response))}
+	}
+
+	for _, f := range e.Formats {
+		schema := &Schema{Type: "string"}
+		if !strings.HasPrefix(f, "text/") {
+			schema.Format = "binary"
+		}
+
+		success.Content[f] = MediaType{Schema: schema}
+	}
+
+	if e.Deprecated {
+		success.Headers = map[string]Header{
+			"Deprecation": {Description: "When the route was deprecated (RFC 9745).", Schema: &Schema{Type: "string"}},
+			"Link":        {Description: "The successor route, rel=\"successor-version\".", Schema: &Schema{Type: "string"}},
+		}
+	}
+
+	op.Responses[strconv.Itoa(status)] = success
+
+	errs := append([]int(nil), e.Errors...)
+	if invalid {
+		errs = append(errs, http.StatusBadRequest)
+	}
+
+	if e.Auth {
+		errs = append(errs, http.StatusUnauthorized, http.StatusForbidden)
+		op.Security = []map[string][]string{{bearerAuth: {}}, {cookieAuth: {}}}
+	}
+
+	if e.RateLimited {
+		errs = append(errs, http.StatusTooManyRequests)
+	}
+
+	problemContent := map[string]MediaType{problem.ContentType: {Schema: problemSchema}}
+
+	for _, code := range errs {
+		r := Response{Description: http.StatusText(code), Content: problemContent}
+		if code == http.StatusTooManyRequests {
+			r.Headers = map[string]Header{
+				"Retry-After": {Description: "Seconds until the next attempt may succeed.", Schema: &Schema{Type: "integer"}},
+			}
+		}
+
+		op.Responses[strconv.Itoa(code)] = r
+	}
+
+	op.Responses["default"] = Response{Description: "Unexpected or upstream error", Content: problemContent}
+
+	return op
+}
+
+// schema returns the schema of t, a reference for named structs, which are added
+// to the components under "<package>.<Type>".
+func (g *generator) schema(t reflect.Type) *Schema {
+	if t.Kind() == reflect.Pointer {
+		s := g.schema(t.Elem())
+		if s.Ref == "" {
+			s.Nullable = true
+		}
+
+		return s
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int32, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Uint, reflect.Uint8, reflect.Uint16:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: g.schema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.schema(t.Elem())}
+	case reflect.Interface:
+		return &Schema{}
+	case reflect.Struct:
+	default:
+		panic(fmt.Sprintf("openapi: unsupported type %s", t))
+	}
+
+	if t.PkgPath() == "time" && t.Name() == "Time" {
+		return &Schema{Type: "string", Format: "date-time"}
+	}
+
+	if t.Name() == "" {
+		return g.object(t)
+	}
+
+	name := t.String()
+	if _, ok := g.schemas[name]; !ok {
+		// Reserve the name first, so a type referring to itself terminates.
+		g.schemas[name] = &Schema{}
+		*g.schemas[name] = *g.object(t)
+	}
+
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+func (g *generator) resolve(s *Schema) *Schema {
+	if s.Ref == "" {
+		return s
+	}
+
+	return g.schemas[strings.TrimPrefix(s.Ref, "#/components/schemas/")]
+}
+
+// object is the schema of a struct as encoding/json writes it: fields named by
+// their json tag and embedded structs inlined. Validate tags become constraints.
+func (g *generator) object(t reflect.Type) *Schema {
+	s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+
+		if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+			embedded := g.object(f.Type)
+			for k, v := range embedded.Properties {
+				s.Properties[k] = v
+			}
+
+			s.Required = append(s.Required, embedded.Required...)
+
+			continue
+		}
+
+		if name == "" {
+			name = f.Name
+		}
+
+		prop := g.schema(f.Type)
+		if constrain(prop, f.Tag.Get("validate")) {
+			s.Required = append(s.Required, name)
+		}
+
+		s.Properties[name] = prop
+	}
+
+	sort.Strings(s.Required)
+
+	return s
+}
+
+// constrain adds the validate rules to s and reports whether the field is required.
+func constrain(s *Schema, rules string) bool {
+	if rules == "" || s.Ref != "" {
+		return false
+	}
+
+	required := false
+
+	for _, rule := range strings.Split(rules, ",") {
+		tag, param, _ := strings.Cut(rule, "=")
+		n, _ := strconv.ParseFloat(param, 64)
+
+		switch tag {
+		case "required":
+			required = true
+		case "email":
+			s.Format = "email"
+		case "notblank":
+			s.Pattern = `\S`
+		case "gt":
+			s.Minimum, s.ExclusiveMinimum = float(n), true
+		case "gte":
+			s.Minimum = float(n)
+		case "lte":
+			s.Maximum = float(n)
+		case "min":
+			if s.Type == "string" {
+				s.MinLength = length(n)
+			} else {
+				s.Minimum = float(n)
+			}
+		case "max":
+			if s.Type == "string" {
+				s.MaxLength = length(n)
+			} else {
+				s.Maximum = float(n)
+			}
+		}
+	}
+
+	return required
+}
+
+// operationID turns "POST /api/v1/loans/{id}/return" into "postApiV1LoansIdReturn".
+func operationID(method string, path string) string {
+	var b strings.Builder
+
+	b.WriteString(strings.ToLower(method))
+
+	for _, word := range strings.FieldsFunc(path, func(r rune) bool {
+		return !('a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || '0' <= r && r <= '9')
+	}) {
+		b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+
+	return b.String()
+}
+
+func float(n float64) *float64 {
+	return &n
+}
+
+func length(n float64) *int {
+	l := int(n)
+
+	return &l
+}
@@ -0,0 +1,136 @@
+package problem
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+const ContentType = "application/problem+json"
+
+// Codes are stable, machine-readable reasons; clients should branch on them
+// rather than on the human-readable detail.
+const (
+	CodeInvalidJSON        = "invalid_json"
+	CodeValidationFailed   = "validation_failed"
+	CodeInvalidQuery       = "invalid_query"
+	CodeInvalidCredentials = "invalid_credentials"
+	CodeUnauthorized       = "unauthorized"
+	CodeForbidden          = "forbidden"
+	CodeCSRFTokenInvalid   = "csrf_token_invalid"
+	CodeWrongAdminSecret   = "wrong_admin_secret"
+	CodeNotFound           = "not_found"
+	CodeUserNotFound       = "user_not_found"
+	CodeBookNotFound       = "book_not_found"
+	CodeStudentNotFound    = "student_not_found"
+	CodeLoanNotFound       = "loan_not_found"
+	CodeUserExists         = "user_exists"
+	CodeAccountDisabled    = "account_disabled"
+	CodeConflict           = "conflict"
+	CodeNoCopies           = "no_copies_available"
+	CodeAlreadyReturned    = "already_returned"
+	CodeAlreadyLost        = "already_reported_lost"
+	CodeMethodNotAllowed   = "method_not_allowed"
+	CodeNotAcceptable      = "not_acceptable"
+	CodeRateLimited        = "rate_limited"
+	CodeUnavailable        = "service_unavailable"
+	CodeInternal           = "internal_error"
+)
+
+// FieldError is one invalid request field, named as in the request.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Problem is an RFC 7807 problem details object with two extension members: a
+// stable code and, for validation failures, the invalid fields.
+type Problem struct {
+	Type      string       `json:"type"`
+	Title     string       `json:"title"`
+	Status    int          `json:"status"`
+	Code      string       `json:"code"`
+	Detail    string       `json:"detail,omitempty"`
+	Instance  string       `json:"instance,omitempty"`
+	Errors    []FieldError `json:"errors,omitempty"`
+	RequestID string       `json:"request_id,omitempty"`
+}
+
+// New returns a problem without a specific type, titled after the status code.
+func New(status int, code string, detail string) Problem {
+	return Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Code:   code,
+		Detail: detail,
+	}
+}
+
+// Write sends p for the request r, filling in the instance and request id.
+func Write(w http.ResponseWriter, r *http.Request, p Problem) {
+	if p.Instance == "" {
+		p.Instance = r.URL.Path
+	}
+
+	p.RequestID = middleware.GetReqID(r.Context())
+
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(p.Status)
+
+	_ = json.NewEncoder(w).Encode(p)
+}
+
+// Error is an error that already knows its problem response. Handlers return or
+// wrap it for failures they detect themselves; Translate builds it for the rest.
+type Error struct {
+	Problem Problem
+	Err     error
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Problem.Code + ": " + e.Err.Error()
+	}
+
+	return e.Problem.Code + ": " + e.Problem.Detail
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func Errorf(status int, code string, detail string, err error) *Error {
+	return &Error{
+		Problem: New(status, code, detail),
+		Err:     err,
+	}
+}
+
+func InvalidJSON(err error) *Error {
+	return Errorf(http.StatusBadRequest, CodeInvalidJSON, "Request body is not valid JSON", err)
+}
+
+func InvalidQuery(detail string, err error) *Error {
+	return Errorf(http.StatusBadRequest, CodeInvalidQuery, detail, err)
+}
+
+func NotFound(code string, detail string) *Error {
+	return Errorf(http.StatusNotFound, code, detail, nil)
+}
+
+func Invalid(fields []FieldError) *Error {
+	e := Errorf(http.StatusBadRequest, CodeValidationFailed, "Request has invalid fields", nil)
+	e.Problem.Errors = fields
+
+	return e
+}
+
+func as(err error) (*Error, bool) {
+	var e *Error
+	ok := errors.As(err, &e)
+
+	return e, ok
+}
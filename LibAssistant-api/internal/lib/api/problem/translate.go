@@ -0,0 +1,175 @@
+package problem
+
+import (
+	"LibAssistant_api/internal/lib/logger/sl"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"reflect"
+	"sort"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type mapping struct {
+	err    error
+	status int
+	code   string
+	detail string
+}
+
+// mappings are the registered errors, checked in registration order.
+var mappings []mapping
+
+// Register makes Translate answer errors matching err (by errors.Is) with the given
+// problem. Packages whose errors reach Render, such as the backend clients, register
+// them from init, so this package does not depend on them.
+func Register(err error, status int, code string, detail string) {
+	mappings = append(mappings, mapping{err: err, status: status, code: code, detail: detail})
+}
+
+// fieldViolations is implemented by errors carrying per-field violations keyed by
+// request field name, such as SSO's.
+type fieldViolations interface {
+	FieldViolations() map[string]string
+}
+
+// Translate turns any handler error into a problem: an *Error as is, request
+// validation failures and backend field violations with their fields, registered
+// errors as registered, other gRPC statuses by their code, the rest as 500.
+func Translate(err error) *Error {
+	if e, ok := as(err); ok {
+		return e
+	}
+
+	var verrs validator.ValidationErrors
+	if errors.As(err, &verrs) {
+		fields := make([]FieldError, 0, len(verrs))
+		for _, fe := range verrs {
+			fields = append(fields, FieldError{Field: fe.Field(), Message: describe(fe)})
+		}
+
+		return wrap(Invalid(fields), err)
+	}
+
+	var fv fieldViolations
+	if errors.As(err, &fv) {
+		violations := fv.FieldViolations()
+
+		names := make([]string, 0, len(violations))
+		for name := range violations {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		fields := make([]FieldError, 0, len(names))
+		for _, name := range names {
+			fields = append(fields, FieldError{Field: name, Message: violations[name]})
+		}
+
+		return wrap(Invalid(fields), err)
+	}
+
+	for _, m := range mappings {
+		if errors.Is(err, m.err) {
+			return Errorf(m.status, m.code, m.detail, err)
+		}
+	}
+
+	if st, ok := status.FromError(err); ok && st.Code() != codes.Unknown {
+		// The status message is the backend's own wording and may leak internals, so
+		// clients get a fixed detail; the message stays in the wrapped error for logs.
+		code := runtime.HTTPStatusFromCode(st.Code())
+
+		return Errorf(code, grpcCode(st.Code()), grpcDetail(st.Code(), code), err)
+	}
+
+	return Errorf(http.StatusInternalServerError, CodeInternal, "Unknown error", err)
+}
+
+// Render translates err, logs it (server errors at error level, client errors at
+// info) and writes the problem response.
+func Render(w http.ResponseWriter, r *http.Request, log *slog.Logger, err error) {
+	e := Translate(err)
+
+	if e.Problem.Status >= http.StatusInternalServerError {
+		log.ErrorContext(r.Context(), "request failed", slog.String("code", e.Problem.Code), sl.Err(err))
+	} else {
+		log.InfoContext(r.Context(), "request rejected", slog.String("code", e.Problem.Code), sl.Err(err))
+	}
+
+	Write(w, r, e.Problem)
+}
+
+func wrap(e *Error, err error) *Error {
+	e.Err = err
+
+	return e
+}
+
+func grpcCode(c codes.Code) string {
+	switch c {
+	case codes.InvalidArgument, codes.OutOfRange, codes.FailedPrecondition:
+		return CodeValidationFailed
+	case codes.Unauthenticated:
+		return CodeUnauthorized
+	case codes.PermissionDenied:
+		return CodeForbidden
+	case codes.NotFound:
+		return CodeNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return CodeConflict
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted:
+		return CodeUnavailable
+	default:
+		return CodeInternal
+	}
+}
+
+func grpcDetail(c codes.Code, status int) string {
+	switch c {
+	case codes.InvalidArgument, codes.OutOfRange:
+		return "A backend service rejected the request"
+	case codes.FailedPrecondition:
+		return "The request cannot be performed in the current state"
+	case codes.AlreadyExists:
+		return "The resource already exists"
+	case codes.Aborted:
+		return "The request conflicted with a concurrent change, try again"
+	case codes.NotFound:
+		return "The resource was not found"
+	default:
+		return http.StatusText(status)
+	}
+}
+
+func describe(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "notblank":
+		return "must not be blank"
+	case "email":
+		return "must be a valid email address"
+	case "min", "gte":
+		if fe.Kind() == reflect.String {
+			return fmt.Sprintf("must be at least %s characters long", fe.Param())
+		}
+
+		return fmt.Sprintf("must be at least %s", fe.Param())
+	case "max", "lte":
+		if fe.Kind() == reflect.String {
+			return fmt.Sprintf("must be at most %s characters long", fe.Param())
+		}
+
+		return fmt.Sprintf("must be at most %s", fe.Param())
+	case "gt":
+		return fmt.Sprintf("must be greater than %s", fe.Param())
+	default:
+		return "is not valid"
+	}
+}
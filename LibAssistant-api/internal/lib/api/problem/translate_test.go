@@ -0,0 +1,154 @@
+package problem
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var errRegistered = errors.New("registered")
+
+func init() {
+	Register(errRegistered, http.StatusConflict, CodeUserExists, "Already there")
+}
+
+type violations map[string]string
+
+func (v violations) Error() string { return "invalid request" }
+
+func (v violations) FieldViolations() map[string]string { return v }
+
+func TestTranslate(t *testing.T) {
+	own := NotFound(CodeBookNotFound, "Book not found")
+
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		code   string
+		detail string
+		fields []FieldError
+	}{
+		{
+			name: "own error", err: own,
+			status: http.StatusNotFound, code: CodeBookNotFound, detail: "Book not found",
+		},
+		{
+			name: "wrapped own error", err: fmt.Errorf("handler: %w", own),
+			status: http.StatusNotFound, code: CodeBookNotFound, detail: "Book not found",
+		},
+		{
+			name: "field violations in name order", err: fmt.Errorf("sso: %w", violations{"password": "too short", "email": "is taken"}),
+			status: http.StatusBadRequest, code: CodeValidationFailed, detail: "Request has invalid fields",
+			fields: []FieldError{{Field: "email", Message: "is taken"}, {Field: "password", Message: "too short"}},
+		},
+		{
+			name: "registered error", err: fmt.Errorf("client: %w", errRegistered),
+			status: http.StatusConflict, code: CodeUserExists, detail: "Already there",
+		},
+		{
+			name: "grpc client error gets a fixed detail", err: status.Error(codes.NotFound, "row 42 missing in table books"),
+			status: http.StatusNotFound, code: CodeNotFound, detail: "The resource was not found",
+		},
+		{
+			name: "grpc invalid argument", err: status.Error(codes.InvalidArgument, "bad field x"),
+			status: http.StatusBadRequest, code: CodeValidationFailed, detail: "A backend service rejected the request",
+		},
+		{
+			name: "grpc permission denied", err: status.Error(codes.PermissionDenied, "role check failed"),
+			status: http.StatusForbidden, code: CodeForbidden, detail: "Forbidden",
+		},
+		{
+			name: "grpc already exists", err: status.Error(codes.AlreadyExists, "duplicate key value violates users_email_key"),
+			status: http.StatusConflict, code: CodeConflict, detail: "The resource already exists",
+		},
+		{
+			name: "grpc aborted", err: status.Error(codes.Aborted, "serialization failure"),
+			status: http.StatusConflict, code: CodeConflict, detail: "The request conflicted with a concurrent change, try again",
+		},
+		{
+			name: "grpc server error", err: status.Error(codes.Internal, "pq: connection reset"),
+			status: http.StatusInternalServerError, code: CodeInternal, detail: "Internal Server Error",
+		},
+		{
+			name: "grpc unavailable", err: status.Error(codes.Unavailable, "dial tcp: refused"),
+			status: http.StatusServiceUnavailable, code: CodeUnavailable, detail: "Service Unavailable",
+		},
+		{
+			name: "unknown error", err: errors.New("boom"),
+			status: http.StatusInternalServerError, code: CodeInternal, detail: "Unknown error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Translate(tt.err)
+
+			assert.Equal(t, tt.status, got.Problem.Status)
+			assert.Equal(t, tt.code, got.Problem.Code)
+			assert.Equal(t, tt.detail, got.Problem.Detail)
+			assert.Equal(t, tt.fields, got.Problem.Errors)
+			// Own errors come back unwrapped; everything else keeps err for the logs.
+			assert.True(t, errors.Is(got, tt.err) || errors.Is(tt.err, got))
+		})
+	}
+}
+
+func TestTranslateValidation(t *testing.T) {
+	type request struct {
+		Email    string `validate:"required,email"`
+		Password string `validate:"min=8"`
+		Copies   int    `validate:"gt=0"`
+	}
+
+	err := validator.New().Struct(request{Email: "nope", Password: "short"})
+	require.Error(t, err)
+
+	got := Translate(err)
+
+	assert.Equal(t, http.StatusBadRequest, got.Problem.Status)
+	assert.Equal(t, CodeValidationFailed, got.Problem.Code)
+	assert.Equal(t, []FieldError{
+		{Field: "Email", Message: "must be a valid email address"},
+		{Field: "Password", Message: "must be at least 8 characters long"},
+		{Field: "Copies", Message: "must be greater than 0"},
+	}, got.Problem.Errors)
+}
+
+func TestDescribe(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		tag   string
+		want  string
+	}{
+		{name: "required", value: "", tag: "required", want: "is required"},
+		{name: "email", value: "nope", tag: "email", want: "must be a valid email address"},
+		{name: "min string", value: "ab", tag: "min=3", want: "must be at least 3 characters long"},
+		{name: "min number", value: 1, tag: "min=3", want: "must be at least 3"},
+		{name: "gte number", value: 1, tag: "gte=3", want: "must be at least 3"},
+		{name: "max string", value: "abcd", tag: "max=3", want: "must be at most 3 characters long"},
+		{name: "max number", value: 400, tag: "max=365", want: "must be at most 365"},
+		{name: "lte number", value: 400, tag: "lte=365", want: "must be at most 365"},
+		{name: "gt", value: 0, tag: "gt=0", want: "must be greater than 0"},
+		{name: "other", value: "x", tag: "uuid", want: "is not valid"},
+	}
+
+	v := validator.New()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var verrs validator.ValidationErrors
+			require.ErrorAs(t, v.Var(tt.value, tt.tag), &verrs)
+
+			assert.Equal(t, tt.want, describe(verrs[0]))
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package request
+
+import (
+	"LibAssistant_api/internal/lib/api/problem"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = newValidator()
+
+func newValidator() *validator.Validate {
+	v := validator.New(validator.WithRequiredStructEnabled())
+
+	// Violations are reported with the JSON field names clients send.
+	v.RegisterTagNameFunc(func(f reflect.StructField) string {
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			return f.Name
+		}
+
+		return name
+	})
+
+	_ = v.RegisterValidation("notblank", func(fl validator.FieldLevel) bool {
+		return strings.TrimSpace(fl.Field().String()) != ""
+	})
+
+	return v
+}
+
+// Decode reads the JSON body of r into v and checks v against its validate tags.
+// An empty body decodes as {}, so requests without required fields fail validation
+// rather than parsing. Errors are ready for problem.Render.
+func Decode(r *http.Request, v any) error {
+	if err := render.DecodeJSON(r.Body, v); err != nil && !errors.Is(err, io.EOF) {
+		return problem.InvalidJSON(err)
+	}
+
+	return validate.Struct(v)
+}
+
+// PathID parses the URL parameter name as a positive id.
+func PathID(r *http.Request, name string) (int64, error) {
+	id, err := strconv.ParseInt(chi.URLParam(r, name), 10, 64)
+	if err != nil || id <= 0 {
+		return 0, problem.Invalid([]problem.FieldError{
+			{Field: name, Message: "must be a positive integer"},
+		})
+	}
+
+	return id, nil
+}
@@ -0,0 +1,107 @@
+package request
+
+import (
+	"LibAssistant_api/internal/lib/api/problem"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type payload struct {
+	Title  string `json:"title" validate:"notblank"`
+	Copies int32  `json:"totalCopies" validate:"required,gt=0"`
+}
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		want     payload
+		wantCode string
+		// invalid are the JSON names of the fields that fail validation.
+		invalid []string
+	}{
+		{name: "valid", body: `{"title":"Dune","totalCopies":3}`, want: payload{Title: "Dune", Copies: 3}},
+		{name: "empty body is {}", body: "", wantCode: problem.CodeValidationFailed, invalid: []string{"title", "totalCopies"}},
+		{name: "blank title", body: `{"title":"  ","totalCopies":1}`, wantCode: problem.CodeValidationFailed, invalid: []string{"title"}},
+		{name: "malformed json", body: `{"title":`, wantCode: problem.CodeInvalidJSON},
+		{name: "wrong type", body: `{"title":"Dune","totalCopies":"three"}`, wantCode: problem.CodeInvalidJSON},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/api/v1/admin/books", strings.NewReader(tt.body))
+
+			var got payload
+			err := Decode(r, &got)
+
+			if tt.wantCode == "" {
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+
+				return
+			}
+
+			p := problem.Translate(err).Problem
+			assert.Equal(t, tt.wantCode, p.Code)
+
+			if tt.invalid != nil {
+				var verrs validator.ValidationErrors
+				require.ErrorAs(t, err, &verrs)
+
+				fields := make([]string, 0, len(p.Errors))
+				for _, fe := range p.Errors {
+					fields = append(fields, fe.Field)
+				}
+
+				assert.Equal(t, tt.invalid, fields)
+			}
+		})
+	}
+}
+
+func TestPathID(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    int64
+		wantErr bool
+	}{
+		{name: "valid", value: "42", want: 42},
+		{name: "zero", value: "0", wantErr: true},
+		{name: "negative", value: "-3", wantErr: true},
+		{name: "not a number", value: "abc", wantErr: true},
+		{name: "empty", value: "", wantErr: true},
+		{name: "overflow", value: "99999999999999999999", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tt.value)
+
+			r := httptest.NewRequest(http.MethodGet, "/api/v1/admin/books/"+tt.value, nil)
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+			got, err := PathID(r, "id")
+
+			if tt.wantErr {
+				p := problem.Translate(err).Problem
+				assert.Equal(t, http.StatusBadRequest, p.Status)
+				assert.Equal(t, []problem.FieldError{{Field: "id", Message: "must be a positive integer"}}, p.Errors)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -1,20 +1,13 @@
 package resp
 
-import (
-	"fmt"
-	"strings"
-
-	"github.com/go-playground/validator/v10"
-)
-
+// Response is the envelope of successful responses; errors are sent as
+// application/problem+json, see package problem.
 type Response struct {
 	Status string `json:"status"`
-	Error  string `json:"error,omitempty"`
 }
 
 const (
-	StatusOK    = "OK"
-	StatusError = "Error"
+	StatusOK = "OK"
 )
 
 func OK() Response {
@@ -22,30 +15,3 @@ func OK() Response {
 		Status: StatusOK,
 	}
 }
-
-func Error(msg string) Response {
-	return Response{
-		Status: StatusError,
-		Error:  msg,
-	}
-}
-
-func ValidationError(errs validator.ValidationErrors) Response {
-	var errMsgs []string
-
-	for _, err := range errs {
-		switch err.ActualTag() {
-		case "required":
-			errMsgs = append(errMsgs, fmt.Sprintf("field %s is a required field", err.Field()))
-		case "email":
-			errMsgs = append(errMsgs, fmt.Sprintf("field %s is not a valid email", err.Error()))
-		default:
-			errMsgs = append(errMsgs, fmt.Sprintf("field %s is not valid", err.Field()))
-		}
-	}
-
-	return Response{
-		Status: StatusError,
-		Error: strings.Join(errMsgs, ", "),
-	}
-}
@@ -1,28 +1,73 @@
 package jwtValidation
 
 import (
+	"errors"
 	"fmt"
-	"os"
+	"slices"
+	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 )
 
-func ValidateToken(tokenString string) error {
-	const op = "middleware.Jwt.ValidateToken"
-	app_secret, ok := os.LookupEnv("APP_SECRET")
-	if !ok {
-		return fmt.Errorf("%s: %s", op, "app secret not found")
-	}
+const (
+	RoleStudent = "student"
+	RoleAdmin   = "admin"
+)
+
+var ErrInvalidToken = errors.New("invalid token")
+
+// Claims are the verified claims of an access token issued by SSO.
+type Claims struct {
+	UserID    int64
+	Email     string
+	Roles     []string
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+}
+
+func (c *Claims) HasRole(role string) bool {
+	return slices.Contains(c.Roles, role)
+}
+
+// tokenClaims is the wire format SSO signs.
+type tokenClaims struct {
+	UID   int64    `json:"uid"`
+	Email string   `json:"email"`
+	Roles []string `json:"roles"`
+	jwt.RegisteredClaims
+}
+
+// ParseToken verifies the signature and expiry of an access token and returns its claims.
+func ParseToken(tokenString string, secret string) (*Claims, error) {
+	const op = "lib.Jwt.ParseToken"
 
-	_, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("%s: %s", op, "unexpected signing method")
-		}
-		return []byte(app_secret), nil
-	})
+	var tc tokenClaims
+
+	_, err := jwt.ParseWithClaims(tokenString, &tc, func(token *jwt.Token) (interface{}, error) {
+		return []byte(secret), nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithExpirationRequired(),
+	)
 	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+		return nil, fmt.Errorf("%s: %w: %w", op, ErrInvalidToken, err)
+	}
+
+	if tc.UID == 0 {
+		return nil, fmt.Errorf("%s: %w: uid claim is missing", op, ErrInvalidToken)
+	}
+
+	claims := &Claims{
+		UserID:    tc.UID,
+		Email:     tc.Email,
+		Roles:     tc.Roles,
+		ExpiresAt: tc.ExpiresAt.Time,
+	}
+
+	// Tokens issued before SSO added "iat" have no issue time and count as stale.
+	if tc.IssuedAt != nil {
+		claims.IssuedAt = tc.IssuedAt.Time
 	}
 
-	return nil
-}
\ No newline at end of file
+	return claims, nil
+}
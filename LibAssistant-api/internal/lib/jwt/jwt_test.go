@@ -0,0 +1,132 @@
+package jwtValidation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const secret = "test-secret"
+
+func sign(t *testing.T, method jwt.SigningMethod, key any, claims jwt.MapClaims) string {
+	t.Helper()
+
+	token, err := jwt.NewWithClaims(method, claims).SignedString(key)
+	require.NoError(t, err)
+
+	return token
+}
+
+func TestParseToken(t *testing.T) {
+	now := time.Now()
+	iat := now.Add(-time.Minute).Truncate(time.Second)
+	exp := now.Add(time.Hour).Truncate(time.Second)
+
+	valid := func() jwt.MapClaims {
+		return jwt.MapClaims{
+			"uid":   int64(42),
+			"email": "reader@example.com",
+			"roles": []string{RoleStudent},
+			"iat":   iat.Unix(),
+			"exp":   exp.Unix(),
+		}
+	}
+
+	without := func(key string) jwt.MapClaims {
+		c := valid()
+		delete(c, key)
+
+		return c
+	}
+
+	with := func(key string, value any) jwt.MapClaims {
+		c := valid()
+		c[key] = value
+
+		return c
+	}
+
+	tests := []struct {
+		name  string
+		token func(t *testing.T) string
+		want  *Claims
+	}{
+		{
+			name:  "valid",
+			token: func(t *testing.T) string { return sign(t, jwt.SigningMethodHS256, []byte(secret), valid()) },
+			want: &Claims{
+				UserID:    42,
+				Email:     "reader@example.com",
+				Roles:     []string{RoleStudent},
+				IssuedAt:  iat,
+				ExpiresAt: exp,
+			},
+		},
+		{
+			name:  "without iat the issue time is zero",
+			token: func(t *testing.T) string { return sign(t, jwt.SigningMethodHS256, []byte(secret), without("iat")) },
+			want: &Claims{
+				UserID:    42,
+				Email:     "reader@example.com",
+				Roles:     []string{RoleStudent},
+				ExpiresAt: exp,
+			},
+		},
+		{
+			name:  "wrong secret",
+			token: func(t *testing.T) string { return sign(t, jwt.SigningMethodHS256, []byte("other"), valid()) },
+		},
+		{
+			name:  "wrong algorithm",
+			token: func(t *testing.T) string { return sign(t, jwt.SigningMethodHS512, []byte(secret), valid()) },
+		},
+		{
+			name: "alg none",
+			token: func(t *testing.T) string {
+				return sign(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, valid())
+			},
+		},
+		{
+			name:  "missing exp",
+			token: func(t *testing.T) string { return sign(t, jwt.SigningMethodHS256, []byte(secret), without("exp")) },
+		},
+		{
+			name: "expired",
+			token: func(t *testing.T) string {
+				return sign(t, jwt.SigningMethodHS256, []byte(secret), with("exp", now.Add(-time.Second).Unix()))
+			},
+		},
+		{
+			name:  "missing uid",
+			token: func(t *testing.T) string { return sign(t, jwt.SigningMethodHS256, []byte(secret), without("uid")) },
+		},
+		{
+			name:  "malformed",
+			token: func(*testing.T) string { return "not.a.token" },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := ParseToken(tt.token(t), secret)
+			if tt.want == nil {
+				assert.ErrorIs(t, err, ErrInvalidToken)
+				assert.Nil(t, claims)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want.UserID, claims.UserID)
+			assert.Equal(t, tt.want.Email, claims.Email)
+			assert.Equal(t, tt.want.Roles, claims.Roles)
+			assert.True(t, tt.want.IssuedAt.Equal(claims.IssuedAt), "issued at %v, want %v", claims.IssuedAt, tt.want.IssuedAt)
+			assert.True(t, tt.want.ExpiresAt.Equal(claims.ExpiresAt), "expires at %v, want %v", claims.ExpiresAt, tt.want.ExpiresAt)
+			assert.True(t, claims.HasRole(RoleStudent))
+			assert.False(t, claims.HasRole(RoleAdmin))
+		})
+	}
+}
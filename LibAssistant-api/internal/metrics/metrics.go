@@ -0,0 +1,55 @@
+package metrics
+
+import (
+	grpcprom "github.com/grpc-ecosystem/go-grpc-middleware/providers/prometheus"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+const namespace = "api"
+
+// Metrics owns the registry exposed on /metrics and every gateway collector.
+type Metrics struct {
+	Registry *prometheus.Registry
+	GRPC     *grpcprom.ClientMetrics
+
+	httpRequests *prometheus.CounterVec
+	httpDuration *prometheus.HistogramVec
+}
+
+func New() *Metrics {
+	m := &Metrics{
+		Registry: prometheus.NewRegistry(),
+		GRPC: grpcprom.NewClientMetrics(
+			grpcprom.WithClientHandlingTimeHistogram(),
+		),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "HTTP requests by route, method and status code.",
+		}, []string{"route", "method", "code"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "HTTP request latency by route and method.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"route", "method"}),
+	}
+
+	m.Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.GRPC,
+		m.httpRequests,
+		m.httpDuration,
+	)
+
+	return m
+}
+
+// ObserveHTTP records a finished request. route is the chi pattern, not the raw path,
+// so ids in URLs do not create a series per request.
+func (m *Metrics) ObserveHTTP(route, method, code string, seconds float64) {
+	m.httpRequests.WithLabelValues(route, method, code).Inc()
+	m.httpDuration.WithLabelValues(route, method).Observe(seconds)
+}
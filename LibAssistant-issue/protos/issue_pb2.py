@@ -25,13 +25,14 @@ _sym_db = _symbol_database.Default()
 from google.protobuf import timestamp_pb2 as google_dot_protobuf_dot_timestamp__pb2
 
 
-DESCRIPTOR = _descriptor_pool.Default().AddSerializedFile(b'\n\x0bissue.proto\x12\x05issue\x1a\x1fgoogle/protobuf/timestamp.proto\"E\n\x0cIssueRequest\x12\x0f\n\x07\x62ook_id\x18\x01 \x01(\x03\x12\x12\n\nstudent_id\x18\x02 \x01(\x03\x12\x10\n\x08\x64\x61ys_due\x18\x03 \x01(\x05\"C\n\rIssueResponse\x12\x0f\n\x07success\x18\x01 \x01(\x08\x12\x0f\n\x07message\x18\x02 \x01(\t\x12\x10\n\x08issue_id\x18\x03 \x01(\x03\"+\n\x18\x43heckAvailabilityRequest\x12\x0f\n\x07\x62ook_id\x18\x01 \x01(\x03\"K\n\x19\x43heckAvailabilityResponse\x12\x18\n\x10\x61vailable_copies\x18\x01 \x01(\x05\x12\x14\n\x0ctotal_copies\x18\x02 \x01(\x05\"!\n\rReturnRequest\x12\x10\n\x08issue_id\x18\x01 \x01(\x03\"@\n\x0eReturnResponse\x12\x0f\n\x07success\x18\x01 \x01(\x08\x12\x0f\n\x07message\x18\x02 \x01(\t\x12\x0c\n\x04\x66ine\x18\x03 \x01(\x05\"%\n\x11ReportLostRequest\x12\x10\n\x08issue_id\x18\x01 \x01(\x03\"D\n\x12ReportLostResponse\x12\x0f\n\x07success\x18\x01 \x01(\x08\x12\x0f\n\x07message\x18\x02 \x01(\t\x12\x0c\n\x04\x66ine\x18\x03 \x01(\x05\"\x14\n\x12GetAllDebtsRequest\"1\n\x13GetAllDebtsResponse\x12\x1a\n\x05\x64\x65\x62ts\x18\x01 \x03(\x0b\x32\x0b.issue.Debt\"#\n\x12ViewDebtorsRequest\x12\r\n\x05limit\x18\x01 \x01(\x05\"E\n\x13ViewDebtorsResponse\x12\x1a\n\x05\x64\x65\x62ts\x18\x01 \x03(\x0b\x32\x0b.issue.Debt\x12\x12\n\nfrom_cache\x18\x02 \x01(\x08\"\xb9\x01\n\x04\x44\x65\x62t\x12\x10\n\x08issue_id\x18\x01 \x01(\x03\x12\x12\n\nstudent_id\x18\x02 \x01(\x03\x12\x14\n\x0cstudent_name\x18\x03 \x01(\t\x12\x0f\n\x07\x62ook_id\x18\x04 \x01(\x03\x12\x12\n\nbook_title\x18\x05 \x01(\t\x12,\n\x08\x64ue_date\x18\x06 \x01(\x0b\x32\x1a.google.protobuf.Timestamp\x12\x14\n\x0coverdue_days\x18\x07 \x01(\x05\x12\x0c\n\x04\x66ine\x18\x08 \x01(\x05\x32\xb4\x03\n\x0cIssueService\x12\x38\n\tIssueBook\x12\x13.issue.IssueRequest\x1a\x14.issue.IssueResponse\"\x00\x12X\n\x11\x43heckAvailability\x12\x1f.issue.CheckAvailabilityRequest\x1a .issue.CheckAvailabilityResponse\"\x00\x12;\n\nReturnBook\x12\x14.issue.ReturnRequest\x1a\x15.issue.ReturnResponse\"\x00\x12\x43\n\nReportLost\x12\x18.issue.ReportLostRequest\x1a\x19.issue.ReportLostResponse\"\x00\x12\x46\n\x0bGetAllDebts\x12\x19.issue.GetAllDebtsRequest\x1a\x1a.issue.GetAllDebtsResponse\"\x00\x12\x46\n\x0bViewDebtors\x12\x19.issue.ViewDebtorsRequest\x1a\x1a.issue.ViewDebtorsResponse\"\x00\x62\x06proto3')
+DESCRIPTOR = _descriptor_pool.Default().AddSerializedFile(b'\n\x0bissue.proto\x12\x05issue\x1a\x1fgoogle/protobuf/timestamp.proto\"E\n\x0cIssueRequest\x12\x0f\n\x07\x62ook_id\x18\x01 \x01(\x03\x12\x12\n\nstudent_id\x18\x02 \x01(\x03\x12\x10\n\x08\x64\x61ys_due\x18\x03 \x01(\x05\"C\n\rIssueResponse\x12\x0f\n\x07success\x18\x01 \x01(\x08\x12\x0f\n\x07message\x18\x02 \x01(\t\x12\x10\n\x08issue_id\x18\x03 \x01(\x03\"+\n\x18\x43heckAvailabilityRequest\x12\x0f\n\x07\x62ook_id\x18\x01 \x01(\x03\"K\n\x19\x43heckAvailabilityResponse\x12\x18\n\x10\x61vailable_copies\x18\x01 \x01(\x05\x12\x14\n\x0ctotal_copies\x18\x02 \x01(\x05\"!\n\rReturnRequest\x12\x10\n\x08issue_id\x18\x01 \x01(\x03\"@\n\x0eReturnResponse\x12\x0f\n\x07success\x18\x01 \x01(\x08\x12\x0f\n\x07message\x18\x02 \x01(\t\x12\x0c\n\x04\x66ine\x18\x03 \x01(\x05\"%\n\x11ReportLostRequest\x12\x10\n\x08issue_id\x18\x01 \x01(\x03\"D\n\x12ReportLostResponse\x12\x0f\n\x07success\x18\x01 \x01(\x08\x12\x0f\n\x07message\x18\x02 \x01(\t\x12\x0c\n\x04\x66ine\x18\x03 \x01(\x05\"\x14\n\x12GetAllDebtsRequest\"1\n\x13GetAllDebtsResponse\x12\x1a\n\x05\x64\x65\x62ts\x18\x01 \x03(\x0b\x32\x0b.issue.Debt\"#\n\x12ViewDebtorsRequest\x12\r\n\x05limit\x18\x01 \x01(\x05\"E\n\x13ViewDebtorsResponse\x12\x1a\n\x05\x64\x65\x62ts\x18\x01 \x03(\x0b\x32\x0b.issue.Debt\x12\x12\n\nfrom_cache\x18\x02 \x01(\x08\"\xb9\x01\n\x04\x44\x65\x62t\x12\x10\n\x08issue_id\x18\x01 \x01(\x03\x12\x12\n\nstudent_id\x18\x02 \x01(\x03\x12\x14\n\x0cstudent_name\x18\x03 \x01(\t\x12\x0f\n\x07\x62ook_id\x18\x04 \x01(\x03\x12\x12\n\nbook_title\x18\x05 \x01(\t\x12,\n\x08\x64ue_date\x18\x06 \x01(\x0b\x32\x1a.google.protobuf.Timestamp\x12\x14\n\x0coverdue_days\x18\x07 \x01(\x05\x12\x0c\n\x04\x66ine\x18\x08 \x01(\x05\"5\n\x0e\x41\x64\x64\x42ookRequest\x12\r\n\x05title\x18\x01 \x01(\t\x12\x14\n\x0ctotal_copies\x18\x02 \x01(\x05\"D\n\x0f\x41\x64\x64\x42ookResponse\x12\x0f\n\x07success\x18\x01 \x01(\x08\x12\x0f\n\x07message\x18\x02 \x01(\t\x12\x0f\n\x07\x62ook_id\x18\x03 \x01(\x03\"!\n\x0eGetBookRequest\x12\x0f\n\x07\x62ook_id\x18\x01 \x01(\x03\"\x83\x01\n\x0fGetBookResponse\x12\x0f\n\x07success\x18\x01 \x01(\x08\x12\x0f\n\x07message\x18\x02 \x01(\t\x12\x0f\n\x07\x62ook_id\x18\x03 \x01(\x03\x12\r\n\x05title\x18\x04 \x01(\t\x12\x14\n\x0ctotal_copies\x18\x05 \x01(\x05\x12\x18\n\x10\x61vailable_copies\x18\x06 \x01(\x05\x32\xac\x04\n\x0cIssueService\x12\x38\n\tIssueBook\x12\x13.issue.IssueRequest\x1a\x14.issue.IssueResponse\"\x00\x12X\n\x11\x43heckAvailability\x12\x1f.issue.CheckAvailabilityRequest\x1a .issue.CheckAvailabilityResponse\"\x00\x12;\n\nReturnBook\x12\x14.issue.ReturnRequest\x1a\x15.issue.ReturnResponse\"\x00\x12\x43\n\nReportLost\x12\x18.issue.ReportLostRequest\x1a\x19.issue.ReportLostResponse\"\x00\x12\x46\n\x0bGetAllDebts\x12\x19.issue.GetAllDebtsRequest\x1a\x1a.issue.GetAllDebtsResponse\"\x00\x12\x46\n\x0bViewDebtors\x12\x19.issue.ViewDebtorsRequest\x1a\x1a.issue.ViewDebtorsResponse\"\x00\x12:\n\x07\x41\x64\x64\x42ook\x12\x15.issue.AddBookRequest\x1a\x16.issue.AddBookResponse\"\x00\x12:\n\x07GetBook\x12\x15.issue.GetBookRequest\x1a\x16.issue.GetBookResponse\"\x00\x42\'Z%LibAssistant_api/gen/go/issue;issuev1b\x06proto3')
 
 _globals = globals()
 _builder.BuildMessageAndEnumDescriptors(DESCRIPTOR, _globals)
 _builder.BuildTopDescriptorsAndMessages(DESCRIPTOR, 'issue_pb2', _globals)
 if not _descriptor._USE_C_DESCRIPTORS:
-  DESCRIPTOR._loaded_options = None
+  _globals['DESCRIPTOR']._loaded_options = None
+  _globals['DESCRIPTOR']._serialized_options = b'Z%LibAssistant_api/gen/go/issue;issuev1'
   _globals['_ISSUEREQUEST']._serialized_start=55
   _globals['_ISSUEREQUEST']._serialized_end=124
   _globals['_ISSUERESPONSE']._serialized_start=126
@@ -58,6 +59,14 @@ if not _descriptor._USE_C_DESCRIPTORS:
   _globals['_VIEWDEBTORSRESPONSE']._serialized_end=706
   _globals['_DEBT']._serialized_start=709
   _globals['_DEBT']._serialized_end=894
-  _globals['_ISSUESERVICE']._serialized_start=897
-  _globals['_ISSUESERVICE']._serialized_end=1333
+  _globals['_ADDBOOKREQUEST']._serialized_start=896
+  _globals['_ADDBOOKREQUEST']._serialized_end=949
+  _globals['_ADDBOOKRESPONSE']._serialized_start=951
+  _globals['_ADDBOOKRESPONSE']._serialized_end=1019
+  _globals['_GETBOOKREQUEST']._serialized_start=1021
+  _globals['_GETBOOKREQUEST']._serialized_end=1054
+  _globals['_GETBOOKRESPONSE']._serialized_start=1057
+  _globals['_GETBOOKRESPONSE']._serialized_end=1188
+  _globals['_ISSUESERVICE']._serialized_start=1191
+  _globals['_ISSUESERVICE']._serialized_end=1747
 # @@protoc_insertion_point(module_scope)
@@ -64,6 +64,16 @@ class IssueServiceStub(object):
                 request_serializer=issue__pb2.ViewDebtorsRequest.SerializeToString,
                 response_deserializer=issue__pb2.ViewDebtorsResponse.FromString,
                 _registered_method=True)
+        self.AddBook = channel.unary_unary(
+                '/issue.IssueService/AddBook',
+                request_serializer=issue__pb2.AddBookRequest.SerializeToString,
+                response_deserializer=issue__pb2.AddBookResponse.FromString,
+                _registered_method=True)
+        self.GetBook = channel.unary_unary(
+                '/issue.IssueService/GetBook',
+                request_serializer=issue__pb2.GetBookRequest.SerializeToString,
+                response_deserializer=issue__pb2.GetBookResponse.FromString,
+                _registered_method=True)
 
 
 class IssueServiceServicer(object):
@@ -105,6 +115,18 @@ class IssueServiceServicer(object):
         context.set_details('Method not implemented!')
         raise NotImplementedError('Method not implemented!')
 
+    def AddBook(self, request, context):
+        """Missing associated documentation comment in .proto file."""
+        context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+        context.set_details('Method not implemented!')
+        raise NotImplementedError('Method not implemented!')
+
+    def GetBook(self, request, context):
+        """Missing associated documentation comment in .proto file."""
+        context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+        context.set_details('Method not implemented!')
+        raise NotImplementedError('Method not implemented!')
+
 
 def add_IssueServiceServicer_to_server(servicer, server):
     rpc_method_handlers = {
@@ -138,6 +160,16 @@ def add_IssueServiceServicer_to_server(servicer, server):
                     request_deserializer=issue__pb2.ViewDebtorsRequest.FromString,
                     response_serializer=issue__pb2.ViewDebtorsResponse.SerializeToString,
             ),
+            'AddBook': grpc.unary_unary_rpc_method_handler(
+                    servicer.AddBook,
+                    request_deserializer=issue__pb2.AddBookRequest.FromString,
+                    response_serializer=issue__pb2.AddBookResponse.SerializeToString,
+            ),
+            'GetBook': grpc.unary_unary_rpc_method_handler(
+                    servicer.GetBook,
+                    request_deserializer=issue__pb2.GetBookRequest.FromString,
+                    response_serializer=issue__pb2.GetBookResponse.SerializeToString,
+            ),
     }
     generic_handler = grpc.method_handlers_generic_handler(
             'issue.IssueService', rpc_method_handlers)
@@ -310,3 +342,57 @@ class IssueService(object):
             timeout,
             metadata,
             _registered_method=True)
+
+    @staticmethod
+    def AddBook(request,
+            target,
+            options=(),
+            channel_credentials=None,
+            call_credentials=None,
+            insecure=False,
+            compression=None,
+            wait_for_ready=None,
+            timeout=None,
+            metadata=None):
+        return grpc.experimental.unary_unary(
+            request,
+            target,
+            '/issue.IssueService/AddBook',
+            issue__pb2.AddBookRequest.SerializeToString,
+            issue__pb2.AddBookResponse.FromString,
+            options,
+            channel_credentials,
+            insecure,
+            call_credentials,
+            compression,
+            wait_for_ready,
+            timeout,
+            metadata,
+            _registered_method=True)
+
+    @staticmethod
+    def GetBook(request,
+            target,
+            options=(),
+            channel_credentials=None,
+            call_credentials=None,
+            insecure=False,
+            compression=None,
+            wait_for_ready=None,
+            timeout=None,
+            metadata=None):
+        return grpc.experimental.unary_unary(
+            request,
+            target,
+            '/issue.IssueService/GetBook',
+            issue__pb2.GetBookRequest.SerializeToString,
+            issue__pb2.GetBookResponse.FromString,
+            options,
+            channel_credentials,
+            insecure,
+            call_credentials,
+            compression,
+            wait_for_ready,
+            timeout,
+            metadata,
+            _registered_method=True)
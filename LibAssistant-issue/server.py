@@ -28,9 +28,8 @@ os.makedirs(LOGS_DIR, exist_ok=True)
 
 
 def compile_proto():
-    # generate pb2 and pb2_grpc under protos/
+    # generate pb2 and pb2_grpc under protos/ unless they are checked in
     proto_file = os.path.join(PROTO_DIR, 'issue.proto')
-    out_py = PROTO_DIR
     if not os.path.exists(os.path.join(PROTO_DIR, 'issue_pb2.py')):
         logger.info('Compiling proto...')
         res = protoc.main((
@@ -224,6 +223,19 @@ class IssueServicer(issue_pb2_grpc.IssueServiceServicer):
         finally:
             session.close()
 
+    def GetBook(self, request, context):
+        session = SessionLocal()
+        try:
+            book = session.query(Book).filter(Book.id == request.book_id).one_or_none()
+            if not book:
+                context.set_code(grpc.StatusCode.NOT_FOUND)
+                context.set_details('Book not found')
+                return issue_pb2.GetBookResponse()
+            return issue_pb2.GetBookResponse(success=True, message='OK', book_id=book.id, title=book.title,
+                                             total_copies=book.total_copies, available_copies=book.available_copies)
+        finally:
+            session.close()
+
 
 
 def serve(port=50051):
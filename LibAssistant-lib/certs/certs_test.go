@@ -0,0 +1,312 @@
+package certs
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"log/slog"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testCA issues certificates for the handshakes below.
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newCA(t *testing.T, name string) *testCA {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return &testCA{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+// issue writes a key pair for localhost signed by ca to dir and returns the paths.
+func (ca *testCA) issue(t *testing.T, dir, name string, serial int64, usage x509.ExtKeyUsage) (certFile, keyFile string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile = filepath.Join(dir, name+".crt")
+	keyFile = filepath.Join(dir, name+".key")
+
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+
+	return certFile, keyFile
+}
+
+func (ca *testCA) write(t *testing.T, dir, name string) string {
+	t.Helper()
+
+	file := filepath.Join(dir, name+".pem")
+	require.NoError(t, os.WriteFile(file, ca.pem, 0o600))
+
+	return file
+}
+
+// handshake runs a TLS handshake over loopback TCP and returns the state seen by
+// the client and the errors of both sides. TCP buffers the alert of a side that
+// rejects the handshake, so neither side blocks.
+func handshake(t *testing.T, server, client *tls.Config) (state tls.ConnectionState, clientErr, serverErr error) {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer lis.Close()
+
+	errc := make(chan error, 1)
+	go func() {
+		conn, err := lis.Accept()
+		if err != nil {
+			errc <- err
+
+			return
+		}
+		defer conn.Close()
+
+		errc <- tls.Server(conn, server).Handshake()
+	}()
+
+	conn, err := net.Dial("tcp", lis.Addr().String())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	c := tls.Client(conn, client)
+	clientErr = c.Handshake()
+
+	// Under TLS 1.3 the client is done before the server has checked its
+	// certificate, so wait for the server's verdict.
+	serverErr = <-errc
+
+	return c.ConnectionState(), clientErr, serverErr
+}
+
+func newReloader(t *testing.T, certFile, keyFile, caFile string) *Reloader {
+	t.Helper()
+
+	r, err := New(slog.New(slog.DiscardHandler), certFile, keyFile, caFile)
+	require.NoError(t, err)
+	t.Cleanup(r.Stop)
+
+	return r
+}
+
+func TestHandshake(t *testing.T) {
+	dir := t.TempDir()
+
+	ca := newCA(t, "ca")
+	other := newCA(t, "other-ca")
+
+	caFile := ca.write(t, dir, "ca")
+	otherFile := other.write(t, dir, "other-ca")
+
+	serverCert, serverKey := ca.issue(t, dir, "server", 10, x509.ExtKeyUsageServerAuth)
+	clientCert, clientKey := ca.issue(t, dir, "client", 20, x509.ExtKeyUsageClientAuth)
+	strangerCert, strangerKey := other.issue(t, dir, "stranger", 30, x509.ExtKeyUsageClientAuth)
+
+	server := newReloader(t, serverCert, serverKey, caFile)
+
+	tests := []struct {
+		name       string
+		clientAuth tls.ClientAuthType
+		client     *Reloader
+		serverName string
+		wantClient bool
+		wantServer bool
+	}{
+		{
+			name:       "mutual TLS",
+			clientAuth: tls.RequireAndVerifyClientCert,
+			client:     newReloader(t, clientCert, clientKey, caFile),
+			serverName: "localhost",
+		},
+		{
+			name:       "server signed by another CA",
+			clientAuth: tls.NoClientCert,
+			client:     newReloader(t, "", "", otherFile),
+			serverName: "localhost",
+			wantClient: true,
+		},
+		{
+			name:       "server name does not match",
+			clientAuth: tls.NoClientCert,
+			client:     newReloader(t, "", "", caFile),
+			serverName: "sso.internal",
+			wantClient: true,
+		},
+		{
+			name:       "missing client certificate",
+			clientAuth: tls.RequireAndVerifyClientCert,
+			client:     newReloader(t, "", "", caFile),
+			serverName: "localhost",
+			wantServer: true,
+		},
+		{
+			name:       "client certificate from another CA",
+			clientAuth: tls.RequireAndVerifyClientCert,
+			client:     newReloader(t, strangerCert, strangerKey, caFile),
+			serverName: "localhost",
+			wantServer: true,
+		},
+		{
+			name:       "client certificate optional",
+			clientAuth: tls.VerifyClientCertIfGiven,
+			client:     newReloader(t, "", "", caFile),
+			serverName: "localhost",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, clientErr, serverErr := handshake(t, server.ServerConfig(tt.clientAuth), tt.client.ClientConfig(tt.serverName))
+
+			if tt.wantClient {
+				assert.Error(t, clientErr, "the client rejects the server")
+			} else {
+				assert.NoError(t, clientErr)
+			}
+
+			if tt.wantServer || tt.wantClient {
+				assert.Error(t, serverErr)
+			} else {
+				assert.NoError(t, serverErr)
+			}
+		})
+	}
+}
+
+func TestServerWithoutCertificate(t *testing.T) {
+	dir := t.TempDir()
+
+	ca := newCA(t, "ca")
+	caFile := ca.write(t, dir, "ca")
+
+	server := newReloader(t, "", "", caFile)
+	client := newReloader(t, "", "", caFile)
+
+	_, clientErr, serverErr := handshake(t, server.ServerConfig(tls.NoClientCert), client.ClientConfig("localhost"))
+	assert.Error(t, clientErr)
+	assert.ErrorIs(t, serverErr, ErrNoKeyPair)
+}
+
+// TestRotation replaces the files in place, as a certificate manager does, and
+// checks new handshakes pick them up while Watch runs.
+func TestRotation(t *testing.T) {
+	dir := t.TempDir()
+
+	oldCA := newCA(t, "ca")
+	nextCA := newCA(t, "ca-next")
+
+	serverCert, serverKey := oldCA.issue(t, dir, "server", 10, x509.ExtKeyUsageServerAuth)
+	caFile := oldCA.write(t, dir, "ca")
+
+	server := newReloader(t, serverCert, serverKey, "")
+	client := newReloader(t, "", "", caFile)
+
+	go server.Watch(10 * time.Millisecond)
+	go client.Watch(10 * time.Millisecond)
+
+	state, clientErr, serverErr := handshake(t, server.ServerConfig(tls.NoClientCert), client.ClientConfig("localhost"))
+	require.NoError(t, clientErr)
+	require.NoError(t, serverErr)
+	assert.Equal(t, int64(10), state.PeerCertificates[0].SerialNumber.Int64())
+
+	// The server moves to a certificate of the next CA; until the client trusts it,
+	// handshakes fail rather than falling back to no verification.
+	nextCA.issue(t, dir, "server", 11, x509.ExtKeyUsageServerAuth)
+	touch(t, serverCert, serverKey)
+
+	require.Eventually(t, func() bool {
+		_, clientErr, _ := handshake(t, server.ServerConfig(tls.NoClientCert), client.ClientConfig("localhost"))
+		return clientErr != nil
+	}, 2*time.Second, 10*time.Millisecond)
+
+	nextCA.write(t, dir, "ca")
+	touch(t, caFile)
+
+	require.Eventually(t, func() bool {
+		state, clientErr, serverErr := handshake(t, server.ServerConfig(tls.NoClientCert), client.ClientConfig("localhost"))
+		return clientErr == nil && serverErr == nil && state.PeerCertificates[0].SerialNumber.Int64() == 11
+	}, 2*time.Second, 10*time.Millisecond)
+}
+
+// TestReloadFailureKeepsCertificates checks a broken file does not replace the
+// certificates in use.
+func TestReloadFailureKeepsCertificates(t *testing.T) {
+	dir := t.TempDir()
+
+	ca := newCA(t, "ca")
+	caFile := ca.write(t, dir, "ca")
+
+	client := newReloader(t, "", "", caFile)
+
+	require.NoError(t, os.WriteFile(caFile, []byte("not a certificate"), 0o600))
+	touch(t, caFile)
+
+	require.True(t, client.changed())
+	require.ErrorIs(t, client.load(), ErrNoCertificates)
+
+	client.mu.RLock()
+	defer client.mu.RUnlock()
+	assert.NotNil(t, client.pool)
+}
+
+// touch moves the modification time forward, as file systems with a coarse clock
+// may not register a rewrite within the same tick.
+func touch(t *testing.T, files ...string) {
+	t.Helper()
+
+	later := time.Now().Add(time.Minute)
+	for _, file := range files {
+		require.NoError(t, os.Chtimes(file, later, later))
+	}
+}
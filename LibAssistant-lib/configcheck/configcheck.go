@@ -0,0 +1,31 @@
+package configcheck
+
+import (
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Run loads the config the way the service does and prints it to out with secrets
+// masked by redact, or prints why it is invalid to errOut. It returns the exit code
+// of the validate subcommand.
+func Run[T any](out, errOut io.Writer, load func() (*T, error), redact func(*T) T) int {
+	cfg, err := load()
+	if err != nil {
+		fmt.Fprintln(errOut, err)
+
+		return 1
+	}
+
+	b, err := yaml.Marshal(redact(cfg))
+	if err != nil {
+		fmt.Fprintln(errOut, "failed to print config:", err)
+
+		return 1
+	}
+
+	fmt.Fprint(out, string(b))
+
+	return 0
+}
@@ -0,0 +1,43 @@
+package configcheck
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type config struct {
+	Address string `yaml:"address"`
+	Secret  string `yaml:"secret"`
+}
+
+func redact(c *config) config {
+	r := *c
+	r.Secret = "masked"
+
+	return r
+}
+
+func TestRun(t *testing.T) {
+	var out, errOut bytes.Buffer
+
+	code := Run(&out, &errOut, func() (*config, error) {
+		return &config{Address: ":8080", Secret: "s3cret"}, nil
+	}, redact)
+
+	assert.Equal(t, 0, code)
+	assert.Equal(t, "address: :8080\nsecret: masked\n", out.String())
+	assert.Empty(t, errOut.String())
+
+	out.Reset()
+
+	code = Run(&out, &errOut, func() (*config, error) {
+		return nil, errors.New("invalid config: app_secret is required")
+	}, redact)
+
+	assert.Equal(t, 1, code)
+	assert.Empty(t, out.String())
+	assert.Equal(t, "invalid config: app_secret is required\n", errOut.String())
+}
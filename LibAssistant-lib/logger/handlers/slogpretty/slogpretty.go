@@ -95,4 +95,3 @@ func (h *PrettyHandler) WithGroup(name string) slog.Handler {
 		l:       h.l,
 	}
 }
-
@@ -0,0 +1,64 @@
+package logger
+
+import (
+	"LibAssistant_lib/logger/handlers/slogpretty"
+	"LibAssistant_lib/tracing"
+	"log/slog"
+	"os"
+)
+
+const (
+	envLocal = "local"
+	envProd  = "prod"
+	envDev   = "dev"
+)
+
+// SetLevel applies the configured level, falling back to the default for env.
+// The level was validated when the config was loaded.
+func SetLevel(level *slog.LevelVar, env string, configured string) {
+	if configured != "" {
+		_ = level.UnmarshalText([]byte(configured))
+
+		return
+	}
+
+	switch env {
+	case envProd:
+		level.Set(slog.LevelInfo)
+	default:
+		level.Set(slog.LevelDebug)
+	}
+}
+
+// Setup returns the logger for env. Records logged with a context that carries
+// a span get its trace_id and span_id.
+func Setup(env string, level slog.Leveler) *slog.Logger {
+	var log *slog.Logger
+
+	switch env {
+	case envLocal:
+		log = setupPrettySlog(level)
+	case envDev:
+		log = slog.New(
+			slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: level}),
+		)
+	case envProd:
+		log = slog.New(
+			slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: level}),
+		)
+	}
+
+	return slog.New(tracing.NewLogHandler(log.Handler()))
+}
+
+func setupPrettySlog(level slog.Leveler) *slog.Logger {
+	opts := slogpretty.PrettyHandlerOptions{
+		SlogOpts: &slog.HandlerOptions{
+			Level: level,
+		},
+	}
+
+	handler := opts.NewPrettyHandler(os.Stdout)
+
+	return slog.New(handler)
+}
@@ -0,0 +1,182 @@
+package reload
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"reflect"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+var ErrUnsafeChange = errors.New("config change requires a restart")
+
+// Check returns ErrUnsafeChange naming every field of next that differs from cur
+// and is not listed in reloadable. cur and next are structs of the same type; fields
+// are named by dotted yaml path, and listing a struct makes its whole subtree reloadable.
+func Check(cur, next any, reloadable map[string]bool) error {
+	var unsafe []string
+	diff("", reflect.ValueOf(cur), reflect.ValueOf(next), reloadable, &unsafe)
+
+	if len(unsafe) > 0 {
+		return fmt.Errorf("%w: %s", ErrUnsafeChange, strings.Join(unsafe, ", "))
+	}
+
+	return nil
+}
+
+func diff(prefix string, cur, upd reflect.Value, reloadable map[string]bool, unsafe *[]string) {
+	t := cur.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("yaml"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+
+		if prefix != "" {
+			name = prefix + "." + name
+		}
+
+		if reloadable[name] {
+			continue
+		}
+
+		if cur.Field(i).Kind() == reflect.Struct {
+			diff(name, cur.Field(i), upd.Field(i), reloadable, unsafe)
+
+			continue
+		}
+
+		if !reflect.DeepEqual(cur.Field(i).Interface(), upd.Field(i).Interface()) {
+			*unsafe = append(*unsafe, name)
+		}
+	}
+}
+
+// Watcher reloads a config file on SIGHUP and when the file changes on disk.
+// A reload is applied as a whole or not at all: if the file cannot be read or
+// changes an unsafe field, the error is logged and the running config is kept.
+type Watcher[T any] struct {
+	log        *slog.Logger
+	path       string
+	load       func(path string) (*T, error)
+	reloadable map[string]bool
+	apply      func(*T)
+
+	mu      sync.Mutex
+	current *T
+	modTime time.Time
+
+	stop chan struct{}
+	once sync.Once
+}
+
+// NewWatcher starts from cfg, loaded from path. load reads the file again on
+// every reload and apply is called with every accepted config.
+func NewWatcher[T any](log *slog.Logger, path string, cfg *T, load func(path string) (*T, error), reloadable map[string]bool, apply func(*T)) *Watcher[T] {
+	w := &Watcher[T]{
+		log:        log,
+		path:       path,
+		load:       load,
+		reloadable: reloadable,
+		apply:      apply,
+		current:    cfg,
+		stop:       make(chan struct{}),
+	}
+
+	if info, err := os.Stat(path); err == nil {
+		w.modTime = info.ModTime()
+	}
+
+	return w
+}
+
+// Watch blocks until Stop is called. A non-positive interval disables polling the file.
+func (w *Watcher[T]) Watch(interval time.Duration) {
+	const op = "reload.Watch"
+
+	log := w.log.With(slog.String("op", op))
+
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	var tick <-chan time.Time
+	if interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		tick = ticker.C
+	}
+
+	for {
+		select {
+		case <-hup:
+			log.Info("reloading config on SIGHUP")
+		case <-tick:
+			if !w.changed() {
+				continue
+			}
+
+			log.Info("config file changed, reloading")
+		case <-w.stop:
+			return
+		}
+
+		if err := w.Reload(); err != nil {
+			log.Error("config reload rejected", slog.String("error", err.Error()))
+
+			continue
+		}
+
+		log.Info("config reloaded")
+	}
+}
+
+// Reload reads the file again and applies it if only reloadable fields changed.
+func (w *Watcher[T]) Reload() error {
+	const op = "reload.Reload"
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if info, err := os.Stat(w.path); err == nil {
+		w.modTime = info.ModTime()
+	}
+
+	next, err := w.load(w.path)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := Check(*w.current, *next, w.reloadable); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	w.apply(next)
+	w.current = next
+
+	return nil
+}
+
+func (w *Watcher[T]) Stop() {
+	w.once.Do(func() { close(w.stop) })
+}
+
+func (w *Watcher[T]) changed() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	info, err := os.Stat(w.path)
+	if err != nil {
+		// Editors often replace the file non-atomically; try again on the next tick.
+		return false
+	}
+
+	return !info.ModTime().Equal(w.modTime)
+}
@@ -0,0 +1,174 @@
+package reload
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type limit struct {
+	Requests int           `yaml:"requests"`
+	Per      time.Duration `yaml:"per"`
+}
+
+type server struct {
+	Address   string            `yaml:"address"`
+	RateLimit map[string]limit  `yaml:"rate_limit"`
+	Timeouts  timeouts          `yaml:"timeouts"`
+	TLS       tls               `yaml:"tls"`
+	Ignored   string            `yaml:"-"`
+	Headers   map[string]string `yaml:"headers,omitempty"`
+}
+
+type timeouts struct {
+	Read  time.Duration `yaml:"read"`
+	Write time.Duration `yaml:"write"`
+}
+
+type tls struct {
+	CertFile string `yaml:"cert_file"`
+	Client   struct {
+		CAFile string `yaml:"ca_file"`
+	} `yaml:"client"`
+}
+
+type config struct {
+	LogLevel string `yaml:"log_level"`
+	Server   server `yaml:"server"`
+	Path     string `yaml:"-"`
+}
+
+var testReloadable = map[string]bool{
+	"log_level":         true,
+	"server.rate_limit": true,
+	"server.timeouts":   true,
+}
+
+func TestCheck(t *testing.T) {
+	base := func() config {
+		return config{
+			LogLevel: "info",
+			Server: server{
+				Address:   ":8080",
+				RateLimit: map[string]limit{"login": {Requests: 5, Per: time.Minute}},
+				Timeouts:  timeouts{Read: time.Second, Write: time.Second},
+			},
+			Path: "a.yaml",
+		}
+	}
+
+	tests := []struct {
+		name   string
+		change func(c *config)
+		unsafe string
+	}{
+		{
+			name:   "no change",
+			change: func(*config) {},
+		},
+		{
+			name:   "reloadable leaf",
+			change: func(c *config) { c.LogLevel = "debug" },
+		},
+		{
+			name:   "reloadable map",
+			change: func(c *config) { c.Server.RateLimit["login"] = limit{Requests: 1, Per: time.Hour} },
+		},
+		{
+			name: "reloadable subtree skipped",
+			change: func(c *config) {
+				c.Server.Timeouts.Read = time.Minute
+				c.Server.Timeouts.Write = time.Minute
+			},
+		},
+		{
+			name:   "fields without a yaml name are ignored",
+			change: func(c *config) { c.Path, c.Server.Ignored = "b.yaml", "x" },
+		},
+		{
+			name:   "top-level unsafe field",
+			change: func(c *config) { c.Server.Address = ":9090" },
+			unsafe: "server.address",
+		},
+		{
+			name:   "nested unsafe field",
+			change: func(c *config) { c.Server.TLS.Client.CAFile = "ca.pem" },
+			unsafe: "server.tls.client.ca_file",
+		},
+		{
+			name:   "tag options are dropped from the name",
+			change: func(c *config) { c.Server.Headers = map[string]string{"X": "1"} },
+			unsafe: "server.headers",
+		},
+		{
+			name: "every unsafe field is named",
+			change: func(c *config) {
+				c.LogLevel = "debug"
+				c.Server.Address = ":9090"
+				c.Server.TLS.CertFile = "cert.pem"
+			},
+			unsafe: "server.address, server.tls.cert_file",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := base()
+			tt.change(&next)
+
+			err := Check(base(), next, testReloadable)
+			if tt.unsafe == "" {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			require.ErrorIs(t, err, ErrUnsafeChange)
+			assert.Equal(t, ErrUnsafeChange.Error()+": "+tt.unsafe, err.Error())
+		})
+	}
+}
+
+func TestWatcherReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, nil, 0o600))
+
+	files := map[string]config{}
+	load := func(string) (*config, error) {
+		c, ok := files["next"]
+		if !ok {
+			return nil, errors.New("cannot read")
+		}
+
+		return &c, nil
+	}
+
+	var applied []*config
+	cur := &config{LogLevel: "info", Server: server{Address: ":8080"}}
+	w := NewWatcher(slog.New(slog.NewTextHandler(io.Discard, nil)), path, cur, load, testReloadable, func(c *config) {
+		applied = append(applied, c)
+	})
+
+	assert.Error(t, w.Reload(), "a file that cannot be loaded is rejected")
+
+	files["next"] = config{LogLevel: "info", Server: server{Address: ":9090"}}
+	assert.ErrorIs(t, w.Reload(), ErrUnsafeChange)
+	assert.Empty(t, applied)
+
+	files["next"] = config{LogLevel: "debug", Server: server{Address: ":8080"}}
+	require.NoError(t, w.Reload())
+	require.Len(t, applied, 1)
+	assert.Equal(t, "debug", applied[0].LogLevel)
+
+	// The accepted config is the base of the next check.
+	files["next"] = config{LogLevel: "warn", Server: server{Address: ":8080"}}
+	require.NoError(t, w.Reload())
+	assert.Len(t, applied, 2)
+}
@@ -0,0 +1,48 @@
+package secrets
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Masked replaces the value of every secret that is set.
+const Masked = "[REDACTED]"
+
+// Secret is a config field holding a credential. Besides the yaml key and the env
+// variable, it can be read from the file named by <Env>_FILE (e.g. a Docker secret),
+// which takes precedence over both.
+type Secret struct {
+	Env   string
+	Value *string
+}
+
+// ReadFiles sets every secret whose <Env>_FILE variable names a file to the
+// contents of that file, without the trailing newline.
+func ReadFiles(secrets []Secret) error {
+	for _, s := range secrets {
+		path := os.Getenv(s.Env + "_FILE")
+		if path == "" {
+			continue
+		}
+
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("cannot read %s_FILE: %w", s.Env, err)
+		}
+
+		*s.Value = strings.TrimRight(string(b), "\r\n")
+	}
+
+	return nil
+}
+
+// Mask replaces every secret that is set with Masked. Empty secrets stay empty,
+// so a missing one is still visible.
+func Mask(secrets []Secret) {
+	for _, s := range secrets {
+		if *s.Value != "" {
+			*s.Value = Masked
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package secrets
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadFiles(t *testing.T) {
+	dir := t.TempDir()
+
+	path := filepath.Join(dir, "app_secret")
+	require.NoError(t, os.WriteFile(path, []byte("from-file\r\n"), 0o600))
+
+	t.Setenv("TEST_APP_SECRET_FILE", path)
+	t.Setenv("TEST_ADMIN_SECRET_FILE", "")
+
+	app, admin := "from-env", "from-yaml"
+	require.NoError(t, ReadFiles([]Secret{
+		{Env: "TEST_APP_SECRET", Value: &app},
+		{Env: "TEST_ADMIN_SECRET", Value: &admin},
+	}))
+
+	assert.Equal(t, "from-file", app, "the file takes precedence and loses its trailing newline")
+	assert.Equal(t, "from-yaml", admin, "without a file the loaded value is kept")
+
+	t.Setenv("TEST_APP_SECRET_FILE", filepath.Join(dir, "missing"))
+
+	err := ReadFiles([]Secret{{Env: "TEST_APP_SECRET", Value: &app}})
+	assert.ErrorContains(t, err, "cannot read TEST_APP_SECRET_FILE")
+}
+
+func TestMask(t *testing.T) {
+	set, unset := "s3cret", ""
+
+	Mask([]Secret{{Env: "SET", Value: &set}, {Env: "UNSET", Value: &unset}})
+
+	assert.Equal(t, Masked, set)
+	assert.Empty(t, unset)
+}
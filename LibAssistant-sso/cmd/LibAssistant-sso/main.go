@@ -1,34 +1,58 @@
 package main
 
 import (
+	"LibAssistant_lib/configcheck"
+	"LibAssistant_lib/logger"
+	"LibAssistant_lib/tracing"
 	"LibAssistant_sso/internal/app"
 	"LibAssistant_sso/internal/config"
-	"LibAssistant_sso/internal/lib/logger/handlers/slogpretty"
+	"LibAssistant_sso/internal/lib/logger/sl"
 	"context"
 	"log/slog"
 	"os"
 	"os/signal"
 	"syscall"
-)
 
-const (
-	envLocal = "local"
-	envProd  = "prod"
-	envDev   = "dev"
+	_ "github.com/joho/godotenv/autoload"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "validate" {
+		os.Args = append(os.Args[:1], os.Args[2:]...)
+		os.Exit(configcheck.Run(os.Stdout, os.Stderr, config.LoadFromFlags, (*config.Config).Redacted))
+	}
+
 	cfg := config.MustLoad()
 
-	log := setupLogger(cfg.Env)
+	level := &slog.LevelVar{}
+	logger.SetLevel(level, cfg.Env, cfg.LogLevel)
+
+	log := logger.Setup(cfg.Env, level)
 
 	log.Info("starting application")
 
 	ctx := context.Background()
 
-	application  := app.New(ctx, log, cfg.Postgres.DBurl, cfg.GRPC.Port, cfg.TokenTTL)
+	shutdownTracing, err := tracing.Setup(ctx, cfg.Tracing)
+	if err != nil {
+		log.Error("failed to set up tracing", sl.Err(err))
+		os.Exit(1)
+	}
+
+	application := app.New(ctx, log, cfg.Postgres.DBurl, cfg.Migrations, cfg.GRPC, cfg.Gateway, cfg.TokenTTL, cfg.AppSecret, cfg.AdminSecret, cfg.Purge, cfg.Outbox, cfg.Events, cfg.Metrics)
 
 	go application.GRPCSrv.MustRun()
+	go application.Gateway.MustRun()
+	go application.Purger.Run()
+	go application.Outbox.Run()
+	go application.Events.Run()
+	go application.Metrics.MustRun()
+
+	watcher := config.NewWatcher(log, cfg, func(next *config.Config) {
+		logger.SetLevel(level, next.Env, next.LogLevel)
+		application.Auth.SetTokenTTL(next.TokenTTL)
+	})
+	go watcher.Watch(cfg.Reload.Interval)
 
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, syscall.SIGTERM, syscall.SIGINT)
@@ -37,38 +61,22 @@ func main() {
 
 	log.Info("stopping application", slog.String("signal", sign.String()))
 
-	application.GRPCSrv.Stop()
+	watcher.Stop()
 
-	log.Info("application stopped")
-}
-
-func setupLogger(env string) *slog.Logger {
-	var log *slog.Logger
-
-	switch env {
-	case envLocal:
-		log = setupPrettySlog()
-	case envDev:
-		log = slog.New(
-			slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}),
-		)
-	case envProd:
-		log = slog.New(
-			slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo}),
-		)
-	}
+	// Ends WatchUserEvents streams, otherwise the graceful stop waits for them forever.
+	application.Events.Stop()
+	application.Gateway.Stop()
+	application.GRPCSrv.Stop()
+	application.Purger.Stop()
+	application.Outbox.Stop()
+	application.Metrics.Stop()
 
-	return log
-}
+	// Closed last: the gRPC handlers, purger and outbox all use the pool until they stop.
+	application.Storage.Close()
 
-func setupPrettySlog() *slog.Logger {
-	opts := slogpretty.PrettyHandlerOptions{
-		SlogOpts: &slog.HandlerOptions{
-			Level: slog.LevelDebug,
-		},
+	if err := shutdownTracing(context.Background()); err != nil {
+		log.Error("failed to flush traces", sl.Err(err))
 	}
 
-	handler := opts.NewPrettyHandler(os.Stdout)
-
-	return slog.New(handler)
+	log.Info("application stopped")
 }
\ No newline at end of file
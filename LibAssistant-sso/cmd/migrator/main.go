@@ -2,16 +2,12 @@ package main
 
 import (
 	"LibAssistant_sso/internal/config"
+	"LibAssistant_sso/internal/migrator"
 	"context"
-	"errors"
-	"fmt"
+	"log/slog"
 	"os"
 	"time"
 
-	"github.com/golang-migrate/migrate/v4"
-	_ "github.com/golang-migrate/migrate/v4/database/postgres"
-	_ "github.com/golang-migrate/migrate/v4/source/file"
-	"github.com/jackc/pgx/v5"
 	_ "github.com/joho/godotenv/autoload"
 )
 
@@ -25,30 +21,9 @@ func main() {
 
 	time.Sleep(3 * time.Second)
 
-	conn, err := pgx.Connect(context.Background(), cfg.Postgres.DBurl)
-	if err != nil {
-		panic(err)
-	}
-	defer conn.Close(context.Background())
+	log := slog.New(slog.NewTextHandler(os.Stdout, nil))
 
-	m, err := migrate.New(
-		"file://"+migrationsPath,
-		cfg.Postgres.DBurl,
-	)
-	if err != nil {
+	if err := migrator.Up(context.Background(), log, cfg.Postgres.DBurl, migrationsPath); err != nil {
 		panic(err)
 	}
-
-	if err := m.Up(); err != nil {
-		if errors.Is(err, migrate.ErrNoChange) {
-			fmt.Println("no migrations to apply")
-
-			return
-		}
-
-		panic(err)
-	}
-
-	fmt.Println("migrations applied successfully")
-
 }
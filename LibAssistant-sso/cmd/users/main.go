@@ -0,0 +1,450 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	usersv1 "LibAssistant_sso/protos/gen/go/LibAssistant/users"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const usage = `usage: users [-addr host:port] [-token token] [-tls-ca ca.pem [-tls-cert c.pem -tls-key k.pem]] <command> [flags]
+
+commands:
+  import -file users.csv [-dry-run]   create accounts from a CSV with columns email,name,class,role
+  export [-format csv|json] [-out f] [-include-deleted]
+                                      write accounts without password hashes
+  delete -id 42                       soft-delete an account
+  restore -id 42                      undo a soft deletion before it is purged
+  set-role -id 42 -role admin         change the role of an account
+  disable -id 42                      block logins of an account
+  enable -id 42                       allow logins again
+  watch [-cursor n] [-types t1,t2]    print user lifecycle events as JSON lines;
+                                      use -timeout 0 to watch without a deadline
+
+The admin token can also be passed in the SSO_ADMIN_TOKEN environment variable.
+`
+
+var csvHeader = []string{"email", "name", "class", "role"}
+
+func main() {
+	addr := flag.String("addr", "localhost:44043", "SSO gRPC address")
+	token := flag.String("token", os.Getenv("SSO_ADMIN_TOKEN"), "admin access token")
+	timeout := flag.Duration("timeout", time.Minute, "request timeout, 0 for none")
+	tlsCA := flag.String("tls-ca", "", "CA bundle to verify the server with; enables TLS")
+	tlsCert := flag.String("tls-cert", "", "client certificate for mutual TLS")
+	tlsKey := flag.String("tls-key", "", "client key for mutual TLS")
+	flag.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if *token == "" {
+		fmt.Fprintln(os.Stderr, "admin token is required")
+		os.Exit(2)
+	}
+
+	creds, err := transportCredentials(*tlsCA, *tlsCert, *tlsKey)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "failed to load tls credentials:", err)
+		os.Exit(1)
+	}
+
+	cc, err := grpc.NewClient(*addr, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "failed to connect to sso:", err)
+		os.Exit(1)
+	}
+	defer cc.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	if *timeout > 0 {
+		ctx, cancel = context.WithTimeout(context.Background(), *timeout)
+	}
+	defer cancel()
+
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+*token)
+
+	client := usersv1.NewUsersClient(cc)
+
+	switch cmd, args := flag.Arg(0), flag.Args()[1:]; cmd {
+	case "import":
+		err = runImport(ctx, client, args)
+	case "export":
+		err = runExport(ctx, client, args)
+	case "delete":
+		err = runDelete(ctx, client, args)
+	case "restore":
+		err = runRestore(ctx, client, args)
+	case "set-role":
+		err = runSetRole(ctx, client, args)
+	case "disable":
+		err = runDisable(ctx, client, args)
+	case "enable":
+		err = runEnable(ctx, client, args)
+	case "watch":
+		err = runWatch(ctx, client, args)
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func runImport(ctx context.Context, client usersv1.UsersClient, args []string) error {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	file := fs.String("file", "", "CSV file to import, - for stdin")
+	dryRun := fs.Bool("dry-run", false, "report what would change without writing")
+	fs.Parse(args)
+
+	if *file == "" {
+		return errors.New("import: -file is required")
+	}
+
+	in := os.Stdin
+	if *file != "-" {
+		f, err := os.Open(*file)
+		if err != nil {
+			return fmt.Errorf("import: %w", err)
+		}
+		defer f.Close()
+		in = f
+	}
+
+	r := csv.NewReader(in)
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+
+	records, err := r.ReadAll()
+	if err != nil {
+		return fmt.Errorf("import: %w", err)
+	}
+
+	if len(records) > 0 && strings.EqualFold(strings.TrimSpace(records[0][0]), csvHeader[0]) {
+		records = records[1:]
+	}
+
+	stream, err := client.ImportUsers(ctx)
+	if err != nil {
+		return fmt.Errorf("import: %w", err)
+	}
+
+	for _, rec := range records {
+		user := &usersv1.User{}
+		fields := []*string{&user.Email, &user.Name, &user.Class, &user.Role}
+		for i := 0; i < len(fields) && i < len(rec); i++ {
+			*fields[i] = rec[i]
+		}
+
+		if err := stream.Send(&usersv1.ImportUsersRequest{User: user, DryRun: *dryRun}); err != nil {
+			break
+		}
+	}
+
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		return fmt.Errorf("import: %w", err)
+	}
+
+	w := csv.NewWriter(os.Stdout)
+	w.Write([]string{"row", "email", "status", "user_id", "initial_password", "error"})
+
+	var failed int
+	for _, res := range resp.GetResults() {
+		if res.GetStatus() == usersv1.ImportStatus_IMPORT_STATUS_FAILED {
+			failed++
+		}
+
+		w.Write([]string{
+			strconv.Itoa(int(res.GetRow())),
+			res.GetEmail(),
+			importStatusName(res.GetStatus()),
+			strconv.FormatInt(res.GetUserId(), 10),
+			res.GetInitialPassword(),
+			res.GetError(),
+		})
+	}
+	w.Flush()
+
+	fmt.Fprintf(os.Stderr, "%d rows, %d failed, dry run: %t\n", len(resp.GetResults()), failed, resp.GetDryRun())
+
+	return w.Error()
+}
+
+func runExport(ctx context.Context, client usersv1.UsersClient, args []string) error {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	format := fs.String("format", "csv", "output format: csv or json")
+	out := fs.String("out", "-", "output file, - for stdout")
+	includeDeleted := fs.Bool("include-deleted", false, "also export soft-deleted accounts")
+	fs.Parse(args)
+
+	if *format != "csv" && *format != "json" {
+		return fmt.Errorf("export: unknown format %q", *format)
+	}
+
+	stream, err := client.ExportUsers(ctx, &usersv1.ExportUsersRequest{IncludeDeleted: *includeDeleted})
+	if err != nil {
+		return fmt.Errorf("export: %w", err)
+	}
+
+	var users []*usersv1.User
+	for {
+		resp, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("export: %w", err)
+		}
+
+		users = append(users, resp.GetUser())
+	}
+
+	w := os.Stdout
+	if *out != "-" {
+		f, err := os.Create(*out)
+		if err != nil {
+			return fmt.Errorf("export: %w", err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	if *format == "json" {
+		type exportedUser struct {
+			ID          int64  `json:"id"`
+			Email       string `json:"email"`
+			Name        string `json:"name"`
+			Class       string `json:"class"`
+			Role        string `json:"role"`
+			CreatedAt   string `json:"created_at"`
+			UpdatedAt   string `json:"updated_at"`
+			LastLoginAt string `json:"last_login_at,omitempty"`
+			DisabledAt  string `json:"disabled_at,omitempty"`
+			DeletedAt   string `json:"deleted_at,omitempty"`
+		}
+
+		list := make([]exportedUser, 0, len(users))
+		for _, u := range users {
+			list = append(list, exportedUser{
+				ID:          u.GetId(),
+				Email:       u.GetEmail(),
+				Name:        u.GetName(),
+				Class:       u.GetClass(),
+				Role:        u.GetRole(),
+				CreatedAt:   formatTimestamp(u.GetCreatedAt()),
+				UpdatedAt:   formatTimestamp(u.GetUpdatedAt()),
+				LastLoginAt: formatTimestamp(u.GetLastLoginAt()),
+				DisabledAt:  formatTimestamp(u.GetDisabledAt()),
+				DeletedAt:   formatTimestamp(u.GetDeletedAt()),
+			})
+		}
+
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+
+		return enc.Encode(list)
+	}
+
+	cw := csv.NewWriter(w)
+	cw.Write(append(append([]string{"id"}, csvHeader...), "created_at", "updated_at", "last_login_at", "disabled_at", "deleted_at"))
+	for _, u := range users {
+		cw.Write([]string{
+			strconv.FormatInt(u.GetId(), 10),
+			u.GetEmail(),
+			u.GetName(),
+			u.GetClass(),
+			u.GetRole(),
+			formatTimestamp(u.GetCreatedAt()),
+			formatTimestamp(u.GetUpdatedAt()),
+			formatTimestamp(u.GetLastLoginAt()),
+			formatTimestamp(u.GetDisabledAt()),
+			formatTimestamp(u.GetDeletedAt()),
+		})
+	}
+	cw.Flush()
+
+	return cw.Error()
+}
+
+func runDelete(ctx context.Context, client usersv1.UsersClient, args []string) error {
+	fs := flag.NewFlagSet("delete", flag.ExitOnError)
+	id := fs.Int64("id", 0, "user id")
+	fs.Parse(args)
+
+	if _, err := client.DeleteUser(ctx, &usersv1.DeleteUserRequest{UserId: *id}); err != nil {
+		return fmt.Errorf("delete: %w", err)
+	}
+
+	fmt.Printf("user %d deleted\n", *id)
+
+	return nil
+}
+
+func runRestore(ctx context.Context, client usersv1.UsersClient, args []string) error {
+	fs := flag.NewFlagSet("restore", flag.ExitOnError)
+	id := fs.Int64("id", 0, "user id")
+	fs.Parse(args)
+
+	if _, err := client.RestoreUser(ctx, &usersv1.RestoreUserRequest{UserId: *id}); err != nil {
+		return fmt.Errorf("restore: %w", err)
+	}
+
+	fmt.Printf("user %d restored\n", *id)
+
+	return nil
+}
+
+func runSetRole(ctx context.Context, client usersv1.UsersClient, args []string) error {
+	fs := flag.NewFlagSet("set-role", flag.ExitOnError)
+	id := fs.Int64("id", 0, "user id")
+	role := fs.String("role", "", "new role: student or admin")
+	fs.Parse(args)
+
+	if _, err := client.SetUserRole(ctx, &usersv1.SetUserRoleRequest{UserId: *id, Role: *role}); err != nil {
+		return fmt.Errorf("set-role: %w", err)
+	}
+
+	fmt.Printf("user %d is now %s\n", *id, *role)
+
+	return nil
+}
+
+func runDisable(ctx context.Context, client usersv1.UsersClient, args []string) error {
+	fs := flag.NewFlagSet("disable", flag.ExitOnError)
+	id := fs.Int64("id", 0, "user id")
+	fs.Parse(args)
+
+	if _, err := client.DisableUser(ctx, &usersv1.DisableUserRequest{UserId: *id}); err != nil {
+		return fmt.Errorf("disable: %w", err)
+	}
+
+	fmt.Printf("user %d disabled\n", *id)
+
+	return nil
+}
+
+func runEnable(ctx context.Context, client usersv1.UsersClient, args []string) error {
+	fs := flag.NewFlagSet("enable", flag.ExitOnError)
+	id := fs.Int64("id", 0, "user id")
+	fs.Parse(args)
+
+	if _, err := client.EnableUser(ctx, &usersv1.EnableUserRequest{UserId: *id}); err != nil {
+		return fmt.Errorf("enable: %w", err)
+	}
+
+	fmt.Printf("user %d enabled\n", *id)
+
+	return nil
+}
+
+func runWatch(ctx context.Context, client usersv1.UsersClient, args []string) error {
+	fs := flag.NewFlagSet("watch", flag.ExitOnError)
+	cursor := fs.Int64("cursor", 0, "cursor of the last processed event")
+	types := fs.String("types", "", "comma separated event types to watch, empty for all")
+	fs.Parse(args)
+
+	req := &usersv1.WatchUserEventsRequest{Cursor: *cursor}
+	if *types != "" {
+		req.Types = strings.Split(*types, ",")
+	}
+
+	stream, err := client.WatchUserEvents(ctx, req)
+	if err != nil {
+		return fmt.Errorf("watch: %w", err)
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+
+	for {
+		event, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("watch: %w", err)
+		}
+
+		enc.Encode(map[string]any{
+			"cursor":      event.GetCursor(),
+			"id":          event.GetId(),
+			"type":        event.GetType(),
+			"user_id":     event.GetUserId(),
+			"email":       event.GetEmail(),
+			"role":        event.GetRole(),
+			"occurred_at": formatTimestamp(event.GetOccurredAt()),
+		})
+	}
+}
+
+func transportCredentials(caFile, certFile, keyFile string) (credentials.TransportCredentials, error) {
+	if caFile == "" {
+		return insecure.NewCredentials(), nil
+	}
+
+	pem, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, err
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates found in %s", caFile)
+	}
+
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12, RootCAs: pool}
+
+	if certFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return credentials.NewTLS(cfg), nil
+}
+
+func formatTimestamp(ts *timestamppb.Timestamp) string {
+	if ts == nil {
+		return ""
+	}
+
+	return ts.AsTime().Format(time.RFC3339)
+}
+
+func importStatusName(s usersv1.ImportStatus) string {
+	switch s {
+	case usersv1.ImportStatus_IMPORT_STATUS_CREATED:
+		return "created"
+	case usersv1.ImportStatus_IMPORT_STATUS_WOULD_CREATE:
+		return "would_create"
+	case usersv1.ImportStatus_IMPORT_STATUS_FAILED:
+		return "failed"
+	default:
+		return "unknown"
+	}
+}
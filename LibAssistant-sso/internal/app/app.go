@@ -1,29 +1,110 @@
 package app
 
 import (
+	"LibAssistant_sso/internal/app/gateway"
 	"LibAssistant_sso/internal/app/grpc"
+	"LibAssistant_sso/internal/app/metrics"
+	"LibAssistant_sso/internal/app/outbox"
+	"LibAssistant_sso/internal/app/purger"
+	"LibAssistant_sso/internal/config"
+	"LibAssistant_sso/internal/gateway"
+	authgrpc "LibAssistant_sso/internal/grpc/auth"
+	"LibAssistant_sso/internal/metrics"
+	"LibAssistant_sso/internal/migrator"
+	"LibAssistant_sso/internal/outbox/file"
+	"LibAssistant_sso/internal/outbox/webhook"
 	"LibAssistant_sso/internal/services/auth"
+	"LibAssistant_sso/internal/services/events"
+	"LibAssistant_sso/internal/services/users"
 	"LibAssistant_sso/internal/storage/postgres"
 	"context"
+	"fmt"
 	"log/slog"
 	"time"
 )
 
 type App struct {
+	Auth    *auth.Auth
 	GRPCSrv *grpcapp.App
+	Gateway *gatewayapp.App
+	Purger  *purgerapp.App
+	Outbox  *outboxapp.App
+	Events  *events.Broker
+	Metrics *metricsapp.App
+	Storage *postgres.Storage
 }
 
-func New(ctx context.Context, log *slog.Logger, dsn string, grpcPort int, tokenTTL time.Duration) *App {
+func New(ctx context.Context, log *slog.Logger, dsn string, migrations config.MigrationsConfig, grpcCfg config.GRPCConfig, gatewayCfg config.GatewayConfig, tokenTTL time.Duration, appSecret string, adminSecret string, purge config.PurgeConfig, outbox config.OutboxConfig, eventsCfg config.EventsConfig, metricsCfg config.MetricsConfig) *App {
+	if migrations.Auto {
+		if err := migrator.Up(ctx, log, dsn, migrations.Path); err != nil {
+			panic(err)
+		}
+	}
+
+	if err := migrator.CheckVersion(ctx, dsn); err != nil {
+		panic(err)
+	}
+
 	storage, err := postgres.New(ctx, dsn)
 	if err != nil {
 		panic(err)
 	}
 
-	authService := auth.New(log, storage, storage, tokenTTL)
+	m := metrics.New()
+	m.RegisterPool(storage.Stat)
+
+	authService := auth.New(log, storage, storage, tokenTTL, appSecret, adminSecret, m)
+
+	usersService := users.New(log, storage, storage)
 
-	grpcApp := grpcapp.New(log, authService, grpcPort)
+	eventBroker := events.New(log, storage, eventsCfg.PollInterval, eventsCfg.SubscriberBuffer)
+
+	grpcApp, err := grpcapp.New(log, authService, usersService, eventBroker, storage, m.GRPC, grpcCfg)
+	if err != nil {
+		panic(err)
+	}
+
+	gw := gateway.New(grpcApp.UnaryInterceptors())
+	if err := gw.Register(authgrpc.GatewayRoutes(authService)...); err != nil {
+		panic(err)
+	}
+
+	gatewayApp := gatewayapp.New(log, gw.Handler("LibAssistant SSO"), gatewayCfg.Port, gatewayCfg.Timeout, grpcApp.TLSConfig())
+
+	purgerApp := purgerapp.New(log, usersService, purge.Interval, purge.Retention)
+
+	sink, err := newOutboxSink(outbox)
+	if err != nil {
+		panic(err)
+	}
+
+	outboxApp := outboxapp.New(log, storage, sink, outbox.Interval, outbox.BatchSize, outbox.Lease)
 
 	return &App{
+		Auth:    authService,
 		GRPCSrv: grpcApp,
+		Gateway: gatewayApp,
+		Purger:  purgerApp,
+		Outbox:  outboxApp,
+		Events:  eventBroker,
+		Metrics: metricsapp.New(log, m.Registry, metricsCfg.Address),
+		Storage: storage,
+	}
+}
+
+func newOutboxSink(cfg config.OutboxConfig) (outboxapp.Sink, error) {
+	switch cfg.Sink {
+	case "webhook":
+		if cfg.Webhook.URL == "" {
+			return nil, fmt.Errorf("outbox webhook url is required")
+		}
+
+		return webhook.New(cfg.Webhook.URL, cfg.Webhook.Timeout), nil
+	case "file":
+		return file.New(cfg.File.Path)
+	case "", "none":
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unknown outbox sink %q", cfg.Sink)
 	}
 }
\ No newline at end of file
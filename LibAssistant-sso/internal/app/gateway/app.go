@@ -0,0 +1,100 @@
+package gatewayapp
+
+import (
+	"LibAssistant_sso/internal/lib/logger/sl"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// App serves the HTTP/JSON gateway to the gRPC API on its own port. It exposes
+// the same API, so it is served with the gRPC server's TLS config, client
+// certificate checks included, whenever gRPC uses TLS.
+type App struct {
+	log    *slog.Logger
+	port   int
+	server *http.Server
+}
+
+// New serves handler over TLS with tlsConfig, or in plain text when it is nil.
+func New(log *slog.Logger, handler http.Handler, port int, timeout time.Duration, tlsConfig *tls.Config) *App {
+	return &App{
+		log:  log,
+		port: port,
+		server: &http.Server{
+			Addr:              fmt.Sprintf(":%d", port),
+			Handler:           withTraceContext(handler),
+			ReadHeaderTimeout: 5 * time.Second,
+			ReadTimeout:       timeout,
+			WriteTimeout:      timeout,
+			TLSConfig:         tlsConfig,
+		},
+	}
+}
+
+// withTraceContext continues a trace propagated by the HTTP caller, like the gRPC
+// server does for gRPC callers.
+func withTraceContext(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func (a *App) MustRun() {
+	if err := a.Run(); err != nil {
+		panic(err)
+	}
+}
+
+// Run blocks until Stop is called. A zero port disables the gateway.
+func (a *App) Run() error {
+	const op = "gatewayapp.Run"
+
+	log := a.log.With(slog.String("op", op))
+
+	if a.port == 0 {
+		log.Info("http gateway disabled")
+
+		return nil
+	}
+
+	log.Info("http gateway is running", slog.String("addr", a.server.Addr), slog.Bool("tls", a.server.TLSConfig != nil))
+
+	var err error
+	if a.server.TLSConfig != nil {
+		// The certificates come from the TLS config, which follows reloads.
+		err = a.server.ListenAndServeTLS("", "")
+	} else {
+		err = a.server.ListenAndServe()
+	}
+
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (a *App) Stop() {
+	const op = "gatewayapp.Stop"
+
+	log := a.log.With(slog.String("op", op))
+
+	log.Info("stopping http gateway")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := a.server.Shutdown(ctx); err != nil {
+		log.Error("failed to stop http gateway", sl.Err(err))
+	}
+}
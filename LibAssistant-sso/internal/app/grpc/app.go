@@ -1,30 +1,189 @@
 package grpcapp
 
 import (
+	"LibAssistant_lib/certs"
+	"LibAssistant_sso/internal/config"
 	authgrpc "LibAssistant_sso/internal/grpc/auth"
+	"LibAssistant_sso/internal/grpc/interceptors"
+	usersgrpc "LibAssistant_sso/internal/grpc/users"
+	"crypto/tls"
 	"fmt"
 	"log/slog"
 	"net"
+	"time"
 
+	grpcprom "github.com/grpc-ecosystem/go-grpc-middleware/providers/prometheus"
+	grpclog "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
+	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/recovery"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 )
 
 type App struct {
 	log        *slog.Logger
 	gRPCServer *grpc.Server
 	port       int
+
+	unary []grpc.UnaryServerInterceptor
+
+	health    *health.Server
+	pinger    Pinger
+	healthCfg config.HealthConfig
+	stop      chan struct{}
+
+	certs          *certs.Reloader
+	tlsConfig      *tls.Config
+	reloadInterval time.Duration
 }
 
-func New(log *slog.Logger, authService authgrpc.Auth, port int) *App {
-	gRPCServer := grpc.NewServer()
+// AuthService serves the Auth API and authenticates admins calling the Users API.
+type AuthService interface {
+	authgrpc.Auth
+	usersgrpc.AdminChecker
+}
+
+func New(log *slog.Logger, authService AuthService, usersService usersgrpc.Users, eventBroker usersgrpc.EventBroker, pinger Pinger, serverMetrics *grpcprom.ServerMetrics, cfg config.GRPCConfig) (*App, error) {
+	const op = "grpcapp.New"
+
+	unary, stream := interceptorChain(log, cfg, serverMetrics)
+
+	opts := []grpc.ServerOption{
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(unary...),
+		grpc.ChainStreamInterceptor(stream...),
+	}
+
+	var (
+		reloader  *certs.Reloader
+		tlsConfig *tls.Config
+	)
+	if cfg.TLS.Enabled {
+		clientAuth, err := parseClientAuth(cfg.TLS.ClientAuth)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		reloader, err = certs.New(log, cfg.TLS.CertFile, cfg.TLS.KeyFile, cfg.TLS.ClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		tlsConfig = reloader.ServerConfig(clientAuth)
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+
+	gRPCServer := grpc.NewServer(opts...)
 
 	authgrpc.Register(gRPCServer, authService)
+	usersgrpc.Register(gRPCServer, usersService, authService, eventBroker)
+
+	serverMetrics.InitializeMetrics(gRPCServer)
 
-	return &App{
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(gRPCServer, healthServer)
+
+	if cfg.Reflection {
+		reflection.Register(gRPCServer)
+	}
+
+	a := &App{
 		log:        log,
 		gRPCServer: gRPCServer,
-		port:       port,
+		port:       cfg.Port,
+		unary:      unary,
+		health:     healthServer,
+		pinger:     pinger,
+		healthCfg:  cfg.Health,
+		stop:       make(chan struct{}),
+
+		certs:          reloader,
+		tlsConfig:      tlsConfig,
+		reloadInterval: cfg.TLS.ReloadInterval,
+	}
+
+	// Nothing is served until the first database ping succeeds.
+	a.setServingStatus(healthpb.HealthCheckResponse_NOT_SERVING)
+
+	return a, nil
+}
+
+// TLSConfig returns the server TLS config, including client certificate checks,
+// or nil when TLS is disabled. Other listeners exposing the same API must use it.
+func (a *App) TLSConfig() *tls.Config {
+	return a.tlsConfig
+}
+
+func parseClientAuth(mode string) (tls.ClientAuthType, error) {
+	switch mode {
+	case "", "none":
+		return tls.NoClientCert, nil
+	case "verify_if_given":
+		return tls.VerifyClientCertIfGiven, nil
+	case "require":
+		return tls.RequireAndVerifyClientCert, nil
+	default:
+		return tls.NoClientCert, fmt.Errorf("unknown tls client auth mode %q", mode)
+	}
+}
+
+// interceptorChain builds the enabled interceptors. Order matters: the request id
+// is attached first so every log line carries it, and recovery runs inside metrics and
+// logging so a recovered panic is counted and logged with codes.Internal.
+func interceptorChain(log *slog.Logger, cfg config.GRPCConfig, serverMetrics *grpcprom.ServerMetrics) ([]grpc.UnaryServerInterceptor, []grpc.StreamServerInterceptor) {
+	ic := cfg.Interceptors
+
+	var (
+		unary  []grpc.UnaryServerInterceptor
+		stream []grpc.StreamServerInterceptor
+	)
+
+	if ic.RequestID {
+		unary = append(unary, interceptors.UnaryRequestID())
+		stream = append(stream, interceptors.StreamRequestID())
+	}
+
+	unary = append(unary, serverMetrics.UnaryServerInterceptor())
+	stream = append(stream, serverMetrics.StreamServerInterceptor())
+
+	if ic.Logging {
+		events := []grpclog.LoggableEvent{grpclog.FinishCall}
+		if ic.LogPayloads {
+			events = append(events, grpclog.PayloadReceived, grpclog.PayloadSent)
+		}
+
+		logOpts := []grpclog.Option{
+			grpclog.WithLogOnEvents(events...),
+			grpclog.WithFieldsFromContext(interceptors.LogFields),
+		}
+
+		unary = append(unary, grpclog.UnaryServerInterceptor(interceptors.Logger(log), logOpts...))
+		stream = append(stream, grpclog.StreamServerInterceptor(interceptors.Logger(log), logOpts...))
+	}
+
+	if ic.Recovery {
+		recoveryOpts := []recovery.Option{
+			recovery.WithRecoveryHandlerContext(interceptors.RecoveryHandler(log)),
+		}
+
+		unary = append(unary, recovery.UnaryServerInterceptor(recoveryOpts...))
+		stream = append(stream, recovery.StreamServerInterceptor(recoveryOpts...))
 	}
+
+	if ic.Deadline {
+		unary = append(unary, interceptors.UnaryDeadline(cfg.Timeout))
+	}
+
+	return unary, stream
+}
+
+// UnaryInterceptors returns the server's unary chain, so other transports serving
+// the same RPCs (the HTTP gateway) get the same request ids, logging, metrics and recovery.
+func (a *App) UnaryInterceptors() []grpc.UnaryServerInterceptor {
+	return a.unary
 }
 
 func (a *App) MustRun() {
@@ -47,6 +206,12 @@ func (a *App) Run() error {
 
 	log.Info("grpc server is running", slog.String("addr", l.Addr().String()))
 
+	go a.watchHealth(a.stop)
+
+	if a.certs != nil {
+		go a.certs.Watch(a.reloadInterval)
+	}
+
 	if err := a.gRPCServer.Serve(l); err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
@@ -59,5 +224,13 @@ func (a *App) Stop() {
 
 	a.log.With(slog.String("op", op)).Info("stopping gRPC server")
 
+	// Report NOT_SERVING for the whole drain, so load balancers stop sending new calls.
+	close(a.stop)
+	a.health.Shutdown()
+
 	a.gRPCServer.GracefulStop()
+
+	if a.certs != nil {
+		a.certs.Stop()
+	}
 }
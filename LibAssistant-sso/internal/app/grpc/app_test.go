@@ -0,0 +1,254 @@
+package grpcapp
+
+import (
+	"LibAssistant_sso/internal/config"
+	"LibAssistant_sso/internal/gateway"
+	authgrpc "LibAssistant_sso/internal/grpc/auth"
+	"LibAssistant_sso/internal/grpc/interceptors"
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	ssov1 "github.com/MKode312/protos/gen/go/LibAssistant/sso"
+	grpcprom "github.com/grpc-ecosystem/go-grpc-middleware/providers/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const panicEmail = "panic@example.com"
+
+// fakeAuth records what the interceptors put in the context of Login and panics
+// for panicEmail.
+type fakeAuth struct {
+	mu        sync.Mutex
+	requestID string
+	deadline  bool
+}
+
+func (f *fakeAuth) Login(ctx context.Context, email, _ string) (string, error) {
+	if email == panicEmail {
+		panic("boom")
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.requestID = interceptors.RequestID(ctx)
+	_, f.deadline = ctx.Deadline()
+
+	return "token", nil
+}
+
+func (f *fakeAuth) RegisterNewUser(context.Context, string, string) (int64, error) { return 0, nil }
+
+func (f *fakeAuth) RegisterNewAdmin(context.Context, string, string, string) (int64, error) {
+	return 0, nil
+}
+
+func (f *fakeAuth) IsAdmin(context.Context, int64) (bool, error) { return false, nil }
+
+func (f *fakeAuth) ParseToken(string) (int64, error) { return 0, nil }
+
+// syncBuffer collects the log lines written by the server goroutines.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.Write(p)
+}
+
+// finishedCalls returns the "finished call" lines of the logging interceptor.
+func (b *syncBuffer) finishedCalls(t *testing.T) []map[string]any {
+	t.Helper()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var lines []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(b.buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+
+		var entry map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+
+		if entry["msg"] == "finished call" {
+			lines = append(lines, entry)
+		}
+	}
+
+	return lines
+}
+
+// transport logs in through the gRPC server or the gateway of a, sending requestID,
+// and returns the echoed request id and the resulting code.
+type transport func(t *testing.T, a *App, auth *fakeAuth, email, requestID string) (string, codes.Code)
+
+func viaGRPC(t *testing.T, a *App, _ *fakeAuth, email, requestID string) (string, codes.Code) {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	go func() { _ = a.gRPCServer.Serve(lis) }()
+
+	cc, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	defer cc.Close()
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), interceptors.RequestIDHeader, requestID)
+
+	var header metadata.MD
+	_, err = ssov1.NewAuthClient(cc).Login(ctx, &ssov1.LoginRequest{Email: email, Password: "secret"}, grpc.Header(&header))
+
+	echoed := ""
+	if values := header.Get(interceptors.RequestIDHeader); len(values) > 0 {
+		echoed = values[0]
+	}
+
+	return echoed, status.Code(err)
+}
+
+func viaGateway(t *testing.T, a *App, auth *fakeAuth, email, requestID string) (string, codes.Code) {
+	t.Helper()
+
+	gw := gateway.New(a.UnaryInterceptors())
+	require.NoError(t, gw.Register(authgrpc.GatewayRoutes(auth)...))
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/auth/login", strings.NewReader(`{"email": "`+email+`", "password": "secret"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Request-Id", requestID)
+
+	rec := httptest.NewRecorder()
+	gw.Handler("test").ServeHTTP(rec, req)
+
+	code := codes.Unknown
+	switch rec.Code {
+	case http.StatusOK:
+		code = codes.OK
+	case http.StatusInternalServerError:
+		code = codes.Internal
+	}
+
+	return rec.Header().Get("X-Request-Id"), code
+}
+
+// TestInterceptorChain checks which interceptors run, and that they run in the
+// documented order, for gRPC calls and for the same RPCs through the gateway.
+func TestInterceptorChain(t *testing.T) {
+	tests := []struct {
+		name         string
+		interceptors config.InterceptorsConfig
+		wantID       bool
+		wantDeadline bool
+		wantLogs     bool
+		recovery     bool
+	}{
+		{
+			name:         "all enabled",
+			interceptors: config.InterceptorsConfig{RequestID: true, Logging: true, Recovery: true, Deadline: true},
+			wantID:       true,
+			wantDeadline: true,
+			wantLogs:     true,
+			recovery:     true,
+		},
+		{
+			name:         "without request ids",
+			interceptors: config.InterceptorsConfig{Logging: true, Recovery: true},
+			wantLogs:     true,
+			recovery:     true,
+		},
+		{
+			name:         "without logging",
+			interceptors: config.InterceptorsConfig{RequestID: true, Recovery: true, Deadline: true},
+			wantID:       true,
+			wantDeadline: true,
+			recovery:     true,
+		},
+		{
+			name:         "deadline only",
+			interceptors: config.InterceptorsConfig{Deadline: true},
+			wantDeadline: true,
+		},
+	}
+
+	transports := map[string]transport{"grpc": viaGRPC, "gateway": viaGateway}
+
+	for _, tt := range tests {
+		for name, call := range transports {
+			t.Run(tt.name+"/"+name, func(t *testing.T) {
+				var logs syncBuffer
+
+				auth := &fakeAuth{}
+				cfg := config.GRPCConfig{Timeout: 5 * time.Second, Interceptors: tt.interceptors}
+
+				a, err := New(slog.New(slog.NewJSONHandler(&logs, nil)), auth, nil, nil, nil, grpcprom.NewServerMetrics(), cfg)
+				require.NoError(t, err)
+				t.Cleanup(a.gRPCServer.Stop)
+
+				echoed, code := call(t, a, auth, "user@example.com", "req-1")
+				require.Equal(t, codes.OK, code)
+
+				auth.mu.Lock()
+				gotID, gotDeadline := auth.requestID, auth.deadline
+				auth.mu.Unlock()
+
+				if tt.wantID {
+					assert.Equal(t, "req-1", gotID)
+					assert.Equal(t, "req-1", echoed)
+				} else {
+					assert.Empty(t, gotID)
+					assert.Empty(t, echoed)
+				}
+
+				assert.Equal(t, tt.wantDeadline, gotDeadline)
+
+				if tt.recovery {
+					_, code = call(t, a, auth, panicEmail, "req-2")
+					assert.Equal(t, codes.Internal, code)
+				}
+
+				calls := logs.finishedCalls(t)
+				if !tt.wantLogs {
+					assert.Empty(t, calls)
+
+					return
+				}
+
+				require.Len(t, calls, 2)
+
+				// The request id is attached before logging, so the log line carries it.
+				if tt.wantID {
+					assert.Equal(t, "req-1", calls[0]["request_id"])
+				} else {
+					assert.NotContains(t, calls[0], "request_id")
+				}
+
+				// Recovery runs inside logging, so the panic is logged as Internal.
+				assert.Equal(t, "OK", calls[0]["grpc.code"])
+				assert.Equal(t, "Internal", calls[1]["grpc.code"])
+			})
+		}
+	}
+}
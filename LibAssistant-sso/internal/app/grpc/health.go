@@ -0,0 +1,76 @@
+package grpcapp
+
+import (
+	"LibAssistant_sso/internal/lib/logger/sl"
+	"context"
+	"log/slog"
+	"time"
+
+	usersv1 "LibAssistant_sso/protos/gen/go/LibAssistant/users"
+
+	ssov1 "github.com/MKode312/protos/gen/go/LibAssistant/sso"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Pinger reports whether the database the services depend on is reachable.
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// healthServices are the names reported by grpc.health.v1. The empty name is the
+// overall server status probed by orchestrators that do not pass a service.
+var healthServices = []string{
+	"",
+	ssov1.Auth_ServiceDesc.ServiceName,
+	usersv1.Users_ServiceDesc.ServiceName,
+}
+
+// watchHealth keeps the serving status in line with database connectivity until stop is closed.
+func (a *App) watchHealth(stop <-chan struct{}) {
+	const op = "grpcapp.watchHealth"
+
+	log := a.log.With(slog.String("op", op))
+
+	ticker := time.NewTicker(a.healthCfg.Interval)
+	defer ticker.Stop()
+
+	serving := false
+
+	for {
+		err := a.ping()
+
+		switch {
+		case err != nil:
+			log.Error("database is unreachable", sl.Err(err))
+
+			if serving {
+				a.setServingStatus(healthpb.HealthCheckResponse_NOT_SERVING)
+				serving = false
+			}
+		case !serving:
+			log.Info("database is reachable, reporting SERVING")
+
+			a.setServingStatus(healthpb.HealthCheckResponse_SERVING)
+			serving = true
+		}
+
+		select {
+		case <-ticker.C:
+		case <-stop:
+			return
+		}
+	}
+}
+
+func (a *App) ping() error {
+	ctx, cancel := context.WithTimeout(context.Background(), a.healthCfg.Timeout)
+	defer cancel()
+
+	return a.pinger.Ping(ctx)
+}
+
+func (a *App) setServingStatus(status healthpb.HealthCheckResponse_ServingStatus) {
+	for _, service := range healthServices {
+		a.health.SetServingStatus(service, status)
+	}
+}
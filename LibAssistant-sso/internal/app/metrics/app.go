@@ -0,0 +1,76 @@
+package metricsapp
+
+import (
+	"LibAssistant_sso/internal/lib/logger/sl"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// App serves Prometheus metrics on a separate listener, so it is not exposed with the gRPC API.
+type App struct {
+	log    *slog.Logger
+	server *http.Server
+}
+
+func New(log *slog.Logger, registry *prometheus.Registry, addr string) *App {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{Registry: registry}))
+
+	return &App{
+		log: log,
+		server: &http.Server{
+			Addr:              addr,
+			Handler:           mux,
+			ReadHeaderTimeout: 5 * time.Second,
+		},
+	}
+}
+
+func (a *App) MustRun() {
+	if err := a.Run(); err != nil {
+		panic(err)
+	}
+}
+
+// Run blocks until Stop is called. An empty address disables the metrics server.
+func (a *App) Run() error {
+	const op = "metricsapp.Run"
+
+	log := a.log.With(slog.String("op", op))
+
+	if a.server.Addr == "" {
+		log.Info("metrics server disabled")
+
+		return nil
+	}
+
+	log.Info("metrics server is running", slog.String("addr", a.server.Addr))
+
+	if err := a.server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (a *App) Stop() {
+	const op = "metricsapp.Stop"
+
+	log := a.log.With(slog.String("op", op))
+
+	log.Info("stopping metrics server")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := a.server.Shutdown(ctx); err != nil {
+		log.Error("failed to stop metrics server", sl.Err(err))
+	}
+}
@@ -0,0 +1,138 @@
+package outboxapp
+
+import (
+	"LibAssistant_sso/internal/domain/models"
+	"LibAssistant_sso/internal/lib/logger/sl"
+	"context"
+	"io"
+	"log/slog"
+	"time"
+)
+
+const maxBackoff = 10 * time.Minute
+
+type Store interface {
+	ProcessOutbox(
+		ctx context.Context,
+		limit int,
+		lease time.Duration,
+		publish func(ctx context.Context, event models.Event) error,
+		backoff func(attempts int) time.Duration,
+	) (published int, failed int, err error)
+}
+
+type Sink interface {
+	Publish(ctx context.Context, event models.Event) error
+}
+
+// App relays outbox events to a sink. Events are marked as published only after
+// the sink accepted them, so delivery is at-least-once.
+type App struct {
+	log       *slog.Logger
+	store     Store
+	sink      Sink
+	interval  time.Duration
+	batchSize int
+	lease     time.Duration
+	stop      chan struct{}
+	done      chan struct{}
+}
+
+// New creates a relay. A nil sink disables relaying.
+func New(log *slog.Logger, store Store, sink Sink, interval time.Duration, batchSize int, lease time.Duration) *App {
+	return &App{
+		log:       log,
+		store:     store,
+		sink:      sink,
+		interval:  interval,
+		batchSize: batchSize,
+		lease:     lease,
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+}
+
+// Run blocks until Stop is called.
+func (a *App) Run() {
+	const op = "outboxapp.Run"
+
+	log := a.log.With(slog.String("op", op))
+
+	defer close(a.done)
+
+	if a.sink == nil {
+		log.Info("outbox relay disabled")
+
+		return
+	}
+
+	log.Info("outbox relay started", slog.String("interval", a.interval.String()))
+
+	ticker := time.NewTicker(a.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			a.drain(log)
+		case <-a.stop:
+			return
+		}
+	}
+}
+
+// drain relays batches until the outbox has no more due events. A batch is cut
+// short when its lease runs out, before other relays may claim its events.
+func (a *App) drain(log *slog.Logger) {
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), a.lease)
+		published, failed, err := a.store.ProcessOutbox(ctx, a.batchSize, a.lease, a.sink.Publish, backoff)
+		cancel()
+
+		if err != nil {
+			log.Error("failed to relay outbox", sl.Err(err))
+
+			return
+		}
+
+		if published > 0 || failed > 0 {
+			log.Info("outbox relayed", slog.Int("published", published), slog.Int("failed", failed))
+		}
+
+		if published+failed < a.batchSize {
+			return
+		}
+
+		select {
+		case <-a.stop:
+			return
+		default:
+		}
+	}
+}
+
+func (a *App) Stop() {
+	const op = "outboxapp.Stop"
+
+	log := a.log.With(slog.String("op", op))
+
+	log.Info("stopping outbox relay")
+
+	close(a.stop)
+	<-a.done
+
+	if closer, ok := a.sink.(io.Closer); ok {
+		if err := closer.Close(); err != nil {
+			log.Error("failed to close outbox sink", sl.Err(err))
+		}
+	}
+}
+
+// backoff doubles the retry delay with every attempt, starting at one second.
+func backoff(attempts int) time.Duration {
+	if attempts > 10 {
+		return maxBackoff
+	}
+
+	return min(time.Second<<(attempts-1), maxBackoff)
+}
@@ -0,0 +1,75 @@
+package purgerapp
+
+import (
+	"LibAssistant_sso/internal/lib/logger/sl"
+	"context"
+	"log/slog"
+	"time"
+)
+
+type Purger interface {
+	PurgeDeleted(ctx context.Context, retention time.Duration) (int64, error)
+}
+
+// App periodically hard-deletes users whose soft deletion is older than the retention period.
+type App struct {
+	log       *slog.Logger
+	purger    Purger
+	interval  time.Duration
+	retention time.Duration
+	stop      chan struct{}
+	done      chan struct{}
+}
+
+func New(log *slog.Logger, purger Purger, interval time.Duration, retention time.Duration) *App {
+	return &App{
+		log:       log,
+		purger:    purger,
+		interval:  interval,
+		retention: retention,
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+}
+
+// Run blocks until Stop is called. A non-positive interval disables purging.
+func (a *App) Run() {
+	const op = "purgerapp.Run"
+
+	log := a.log.With(slog.String("op", op))
+
+	defer close(a.done)
+
+	if a.interval <= 0 {
+		log.Info("purge job disabled")
+
+		return
+	}
+
+	log.Info("purge job started", slog.String("interval", a.interval.String()), slog.String("retention", a.retention.String()))
+
+	ticker := time.NewTicker(a.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), a.interval)
+			if _, err := a.purger.PurgeDeleted(ctx, a.retention); err != nil {
+				log.Error("purge failed", sl.Err(err))
+			}
+			cancel()
+		case <-a.stop:
+			return
+		}
+	}
+}
+
+func (a *App) Stop() {
+	const op = "purgerapp.Stop"
+
+	a.log.With(slog.String("op", op)).Info("stopping purge job")
+
+	close(a.stop)
+	<-a.done
+}
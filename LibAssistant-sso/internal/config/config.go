@@ -1,6 +1,8 @@
 package config
 
 import (
+	"LibAssistant_lib/tracing"
+	"errors"
 	"flag"
 	"fmt"
 	"os"
@@ -10,52 +12,183 @@ import (
 )
 
 type Config struct {
-	Env         string         `yaml:"env" env-default:"local"`
-	TokenTTL    time.Duration  `yaml:"token_ttl" env-required:"true"`
-	GRPC        GRPCConfig     `yaml:"grpc"`
-	Postgres    PostgresConfig `yaml:"postgres"`
+	Env         string           `yaml:"env" env-default:"local"`
+	LogLevel    string           `yaml:"log_level" env:"LOG_LEVEL"`
+	TokenTTL    time.Duration    `yaml:"token_ttl" env-required:"true"`
+	AppSecret   string           `yaml:"app_secret" env:"APP_SECRET"`
+	AdminSecret string           `yaml:"admin_secret" env:"ADMIN_SECRET"`
+	Reload      ReloadConfig     `yaml:"reload"`
+	GRPC        GRPCConfig       `yaml:"grpc"`
+	Gateway     GatewayConfig    `yaml:"gateway"`
+	Postgres    PostgresConfig   `yaml:"postgres"`
+	Migrations  MigrationsConfig `yaml:"migrations"`
+	Purge       PurgeConfig      `yaml:"purge"`
+	Outbox      OutboxConfig     `yaml:"outbox"`
+	Events      EventsConfig     `yaml:"events"`
+	Metrics     MetricsConfig    `yaml:"metrics"`
+	Tracing     tracing.Config   `yaml:"tracing"`
+
+	// Path is the file the config was loaded from, kept for reloads.
+	Path string `yaml:"-"`
 }
 
 type GRPCConfig struct {
-	Port    int           `yaml:"port"`
-	Timeout time.Duration `yaml:"timeout"`
+	Port         int                `yaml:"port"`
+	Timeout      time.Duration      `yaml:"timeout"`
+	Interceptors InterceptorsConfig `yaml:"interceptors"`
+	Health       HealthConfig       `yaml:"health"`
+	Reflection   bool               `yaml:"reflection" env:"GRPC_REFLECTION" env-default:"false"`
+	TLS          TLSConfig          `yaml:"tls"`
+}
+
+// TLSConfig enables TLS on the gRPC server. With ClientCAFile set, client certificates
+// are checked according to ClientAuth: "none", "verify_if_given" or "require".
+// Files are polled every ReloadInterval and reloaded when they change.
+type TLSConfig struct {
+	Enabled        bool          `yaml:"enabled" env:"GRPC_TLS_ENABLED" env-default:"false"`
+	CertFile       string        `yaml:"cert_file" env:"GRPC_TLS_CERT_FILE"`
+	KeyFile        string        `yaml:"key_file" env:"GRPC_TLS_KEY_FILE"`
+	ClientCAFile   string        `yaml:"client_ca_file" env:"GRPC_TLS_CLIENT_CA_FILE"`
+	ClientAuth     string        `yaml:"client_auth" env:"GRPC_TLS_CLIENT_AUTH" env-default:"none"`
+	ReloadInterval time.Duration `yaml:"reload_interval" env-default:"30s"`
+}
+
+// GatewayConfig sets the port the HTTP/JSON gateway to the Auth API is served on;
+// 0 disables it. Calls use the gRPC interceptors, including the deadline, and the
+// gateway is served with grpc.tls, client certificate checks included, when enabled.
+type GatewayConfig struct {
+	Port    int           `yaml:"port" env:"GATEWAY_PORT" env-default:"0"`
+	Timeout time.Duration `yaml:"timeout" env-default:"10s"`
+}
+
+// HealthConfig controls how often grpc.health.v1 status is refreshed from the database.
+type HealthConfig struct {
+	Interval time.Duration `yaml:"interval" env-default:"5s"`
+	Timeout  time.Duration `yaml:"timeout" env-default:"2s"`
+}
+
+// InterceptorsConfig switches the parts of the gRPC server interceptor chain.
+// Deadlines use GRPCConfig.Timeout and apply to unary calls only.
+type InterceptorsConfig struct {
+	RequestID   bool `yaml:"request_id" env-default:"true"`
+	Logging     bool `yaml:"logging" env-default:"true"`
+	LogPayloads bool `yaml:"log_payloads" env-default:"false"`
+	Recovery    bool `yaml:"recovery" env-default:"true"`
+	Deadline    bool `yaml:"deadline" env-default:"true"`
 }
 
 type PostgresConfig struct {
 	Port     int    `yaml:"port"`
 	Name     string `yaml:"name"`
 	User     string `yaml:"user"`
-	Password string `yaml:"password"`
+	Password string `yaml:"password" env:"DB_PASSWORD"`
 	Host     string `yaml:"host"`
-	DBurl    string
+	DBurl    string `yaml:"-"`
+}
+
+type MigrationsConfig struct {
+	Auto bool   `yaml:"auto" env:"MIGRATIONS_AUTO" env-default:"false"`
+	Path string `yaml:"path" env:"MIGRATIONS_PATH" env-default:"./migrations"`
+}
+
+// PurgeConfig controls the job that hard-deletes soft-deleted users.
+type PurgeConfig struct {
+	Interval  time.Duration `yaml:"interval" env-default:"1h"`
+	Retention time.Duration `yaml:"retention" env-default:"720h"`
+}
+
+// OutboxConfig controls the relay that publishes user lifecycle events.
+// Sink is one of "webhook", "file" or "none". Lease is how long a relay may work
+// on a batch before its unfinished events can be claimed by another one.
+type OutboxConfig struct {
+	Sink      string            `yaml:"sink" env:"OUTBOX_SINK" env-default:"none"`
+	Interval  time.Duration     `yaml:"interval" env-default:"1s"`
+	BatchSize int               `yaml:"batch_size" env-default:"100"`
+	Lease     time.Duration     `yaml:"lease" env-default:"1m"`
+	Webhook   WebhookSinkConfig `yaml:"webhook"`
+	File      FileSinkConfig    `yaml:"file"`
+}
+
+type WebhookSinkConfig struct {
+	URL     string        `yaml:"url" env:"OUTBOX_WEBHOOK_URL"`
+	Timeout time.Duration `yaml:"timeout" env-default:"5s"`
+}
+
+type FileSinkConfig struct {
+	Path string `yaml:"path" env-default:"./events.jsonl"`
+}
+
+// EventsConfig controls the WatchUserEvents stream.
+type EventsConfig struct {
+	PollInterval     time.Duration `yaml:"poll_interval" env-default:"500ms"`
+	SubscriberBuffer int           `yaml:"subscriber_buffer" env-default:"256"`
+}
+
+// ReloadConfig controls how often the config file is checked for changes; 0 disables
+// polling, SIGHUP always triggers a reload.
+type ReloadConfig struct {
+	Interval time.Duration `yaml:"interval" env-default:"5s"`
+}
+
+// MetricsConfig sets the address Prometheus metrics are served on; empty disables them.
+type MetricsConfig struct {
+	Address string `yaml:"address" env:"METRICS_ADDRESS" env-default:"0.0.0.0:9090"`
 }
 
 func MustLoad() *Config {
+	cfg, err := LoadFromFlags()
+	if err != nil {
+		panic(err)
+	}
+
+	return cfg
+}
+
+// LoadFromFlags loads the config named by the -config flag or CONFIG_PATH.
+func LoadFromFlags() (*Config, error) {
 	path := fetchConfigPath()
 
 	if path == "" {
-		panic("config path is empty")
+		return nil, errors.New("config path is empty")
 	}
 
-	cfg := MustLoadByPath(path)
-	
-	cfg.Postgres.DBurl = fmt.Sprintf("postgres://%s:%s@%s:%d/%s?sslmode=disable", cfg.Postgres.User, cfg.Postgres.Password, cfg.Postgres.Host, cfg.Postgres.Port, cfg.Postgres.Name)
+	return Load(path)
+}
+
+func MustLoadByPath(configPath string) *Config {
+	cfg, err := Load(configPath)
+	if err != nil {
+		panic(err)
+	}
 
 	return cfg
 }
 
-func MustLoadByPath(configPath string) *Config {
+// Load reads the config file, environment overrides and secret files, then validates
+// the result. It does not panic, so it is also used for reloads.
+func Load(configPath string) (*Config, error) {
 	if _, err := os.Stat(configPath); os.IsNotExist(err) {
-		panic("config file does not exist: " + configPath)
+		return nil, fmt.Errorf("config file does not exist: %s", configPath)
 	}
 
 	var cfg Config
 
 	if err := cleanenv.ReadConfig(configPath, &cfg); err != nil {
-		panic("cannot read config: " + err.Error())
+		return nil, fmt.Errorf("cannot read config: %w", err)
+	}
+
+	if err := cfg.readSecretFiles(); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
 	}
 
-	return &cfg
+	cfg.Path = configPath
+	cfg.Postgres.DBurl = fmt.Sprintf("postgres://%s:%s@%s:%d/%s?sslmode=disable", cfg.Postgres.User, cfg.Postgres.Password, cfg.Postgres.Host, cfg.Postgres.Port, cfg.Postgres.Name)
+
+	return &cfg, nil
 }
 
 func fetchConfigPath() string {
@@ -0,0 +1,69 @@
+package config
+
+import (
+	"LibAssistant_lib/secrets"
+	"errors"
+	"fmt"
+	"log/slog"
+)
+
+// secrets lists the credentials in c; see secrets.Secret for how they are read.
+func (c *Config) secrets() []secrets.Secret {
+	return []secrets.Secret{
+		{Env: "APP_SECRET", Value: &c.AppSecret},
+		{Env: "ADMIN_SECRET", Value: &c.AdminSecret},
+		{Env: "DB_PASSWORD", Value: &c.Postgres.Password},
+	}
+}
+
+func (c *Config) readSecretFiles() error {
+	return secrets.ReadFiles(c.secrets())
+}
+
+// Validate reports every required field that is missing or invalid, not just the first.
+func (c *Config) Validate() error {
+	var errs []error
+
+	if c.TokenTTL <= 0 {
+		errs = append(errs, errors.New("token_ttl must be positive"))
+	}
+
+	if c.AppSecret == "" {
+		errs = append(errs, errors.New("app_secret is required (APP_SECRET or APP_SECRET_FILE)"))
+	}
+
+	if c.AdminSecret == "" {
+		errs = append(errs, errors.New("admin_secret is required (ADMIN_SECRET or ADMIN_SECRET_FILE)"))
+	}
+
+	if c.GRPC.Port == 0 {
+		errs = append(errs, errors.New("grpc.port is required"))
+	}
+
+	if c.GRPC.TLS.Enabled && (c.GRPC.TLS.CertFile == "" || c.GRPC.TLS.KeyFile == "") {
+		errs = append(errs, errors.New("grpc.tls.cert_file and grpc.tls.key_file are required when grpc.tls.enabled is set"))
+	}
+
+	if c.Postgres.Host == "" || c.Postgres.Name == "" || c.Postgres.User == "" {
+		errs = append(errs, errors.New("postgres.host, postgres.name and postgres.user are required"))
+	}
+
+	if c.LogLevel != "" {
+		var level slog.Level
+		if err := level.UnmarshalText([]byte(c.LogLevel)); err != nil {
+			errs = append(errs, fmt.Errorf("invalid log_level: %w", err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Redacted returns a copy of c with secrets masked, safe to print or log.
+func (c *Config) Redacted() Config {
+	r := *c
+	secrets.Mask(r.secrets())
+
+	r.Postgres.DBurl = ""
+
+	return r
+}
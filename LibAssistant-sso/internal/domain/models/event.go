@@ -0,0 +1,55 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	EventUserRegistered  = "user.registered"
+	EventUserRoleChanged = "user.role_changed"
+	EventUserDeleted     = "user.deleted"
+	EventUserDisabled    = "user.disabled"
+	EventUserEnabled     = "user.enabled"
+	EventUserRestored    = "user.restored"
+)
+
+// Event is a user lifecycle event stored in the outbox.
+type Event struct {
+	// ID is the position of the event in the outbox.
+	ID int64
+	// EventID is a unique id consumers use to drop duplicate deliveries.
+	EventID   string
+	Type      string
+	UserID    int64
+	Payload   []byte
+	CreatedAt time.Time
+	Attempts  int
+}
+
+// UserEventPayload is the JSON body of every user lifecycle event.
+type UserEventPayload struct {
+	UserID     int64     `json:"user_id"`
+	Email      string    `json:"email"`
+	Role       string    `json:"role"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// EventEnvelope is the wire format delivered by outbox sinks.
+type EventEnvelope struct {
+	ID        string          `json:"id"`
+	Type      string          `json:"type"`
+	UserID    int64           `json:"user_id"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+func (e Event) Envelope() EventEnvelope {
+	return EventEnvelope{
+		ID:        e.EventID,
+		Type:      e.Type,
+		UserID:    e.UserID,
+		CreatedAt: e.CreatedAt,
+		Data:      json.RawMessage(e.Payload),
+	}
+}
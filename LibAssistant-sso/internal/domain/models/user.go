@@ -1,7 +1,31 @@
 package models
 
+import "time"
+
+const (
+	RoleStudent = "student"
+	RoleAdmin   = "admin"
+)
+
 type User struct {
-	Email    string
-	ID       int64
-	PassHash []byte
+	Email       string
+	ID          int64
+	PassHash    []byte
+	Name        string
+	Class       string
+	IsAdmin     bool
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	LastLoginAt *time.Time
+	DisabledAt  *time.Time
+	DeletedAt   *time.Time
+}
+
+// Role returns the role name used in imports and exports.
+func (u User) Role() string {
+	if u.IsAdmin {
+		return RoleAdmin
+	}
+
+	return RoleStudent
 }
@@ -0,0 +1,213 @@
+// Package gateway serves unary gRPC methods as HTTP/JSON for clients that cannot
+// speak gRPC. The shared Auth protos carry no google.api.http annotations, so routes
+// are declared in Go instead of generated, but requests are handled the way
+// grpc-gateway's generated server handlers do it: the JSON body and path parameters
+// are decoded into the request message, the call goes through the same unary
+// interceptors as the gRPC server, and errors are mapped from gRPC status codes.
+package gateway
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/textproto"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// requestIDHeader is forwarded in both directions, so HTTP callers can correlate
+// their request with the SSO logs like gRPC callers do.
+const requestIDHeader = "X-Request-Id"
+
+// Route maps an HTTP method and path to a unary RPC. Path parameters such as
+// {user_id} are copied into the request field of the same name.
+type Route struct {
+	Method     string
+	Path       string
+	FullMethod string
+	Summary    string
+
+	input  protoreflect.MessageType
+	output protoreflect.MessageType
+	call   func(ctx context.Context, req proto.Message) (proto.Message, error)
+}
+
+// Unary declares a route for a unary method of a gRPC server implementation.
+func Unary[Req, Resp proto.Message](method, path, fullMethod, summary string, call func(context.Context, Req) (Resp, error)) Route {
+	var (
+		req  Req
+		resp Resp
+	)
+
+	return Route{
+		Method:     method,
+		Path:       path,
+		FullMethod: fullMethod,
+		Summary:    summary,
+		input:      req.ProtoReflect().Type(),
+		output:     resp.ProtoReflect().Type(),
+		call: func(ctx context.Context, in proto.Message) (proto.Message, error) {
+			return call(ctx, in.(Req))
+		},
+	}
+}
+
+type Gateway struct {
+	mux          *runtime.ServeMux
+	interceptors []grpc.UnaryServerInterceptor
+	routes       []Route
+}
+
+// New creates a gateway whose calls go through interceptors, in order.
+func New(interceptors []grpc.UnaryServerInterceptor) *Gateway {
+	marshaler := &runtime.JSONPb{
+		MarshalOptions: protojson.MarshalOptions{
+			UseProtoNames:   true,
+			EmitUnpopulated: true,
+		},
+		UnmarshalOptions: protojson.UnmarshalOptions{
+			DiscardUnknown: true,
+		},
+	}
+
+	mux := runtime.NewServeMux(
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, marshaler),
+		runtime.WithIncomingHeaderMatcher(incomingHeader),
+		runtime.WithOutgoingHeaderMatcher(outgoingHeader),
+	)
+
+	return &Gateway{
+		mux:          mux,
+		interceptors: interceptors,
+	}
+}
+
+func (g *Gateway) Register(routes ...Route) error {
+	for _, rt := range routes {
+		if err := g.mux.HandlePath(rt.Method, rt.Path, g.handle(rt)); err != nil {
+			return err
+		}
+
+		g.routes = append(g.routes, rt)
+	}
+
+	return nil
+}
+
+// Handler serves the registered routes and their OpenAPI document at /openapi.json.
+func (g *Gateway) Handler(title string) http.Handler {
+	spec := g.openAPI(title)
+
+	mux := http.NewServeMux()
+	mux.Handle("/", g.mux)
+	mux.HandleFunc("GET /openapi.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(spec)
+	})
+
+	return mux
+}
+
+func (g *Gateway) handle(rt Route) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		ctx, cancel := context.WithCancel(r.Context())
+		defer cancel()
+
+		// Collects headers set with grpc.SetHeader, e.g. by the request id interceptor.
+		var stream runtime.ServerTransportStream
+		ctx = grpc.NewContextWithServerTransportStream(ctx, &stream)
+
+		inbound, outbound := runtime.MarshalerForRequest(g.mux, r)
+
+		ctx, err := runtime.AnnotateIncomingContext(ctx, g.mux, r, rt.FullMethod, runtime.WithHTTPPathPattern(rt.Path))
+		if err != nil {
+			runtime.HTTPError(ctx, g.mux, outbound, w, r, err)
+
+			return
+		}
+
+		var resp proto.Message
+
+		req, err := decode(rt, inbound, r, pathParams)
+		if err == nil {
+			resp, err = g.invoke(ctx, rt, req)
+		}
+
+		ctx = runtime.NewServerMetadataContext(ctx, runtime.ServerMetadata{
+			HeaderMD:  stream.Header(),
+			TrailerMD: stream.Trailer(),
+		})
+
+		if err != nil {
+			runtime.HTTPError(ctx, g.mux, outbound, w, r, err)
+
+			return
+		}
+
+		runtime.ForwardResponseMessage(ctx, g.mux, outbound, w, r, resp, g.mux.GetForwardResponseOptions()...)
+	}
+}
+
+func decode(rt Route, marshaler runtime.Marshaler, r *http.Request, pathParams map[string]string) (proto.Message, error) {
+	req := rt.input.New().Interface()
+
+	if r.Body != nil && r.Method != http.MethodGet {
+		if err := marshaler.NewDecoder(r.Body).Decode(req); err != nil && !errors.Is(err, io.EOF) {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid request body: %v", err)
+		}
+	}
+
+	for field, value := range pathParams {
+		if err := runtime.PopulateFieldFromPath(req, field, value); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid %s: %v", field, err)
+		}
+	}
+
+	return req, nil
+}
+
+func (g *Gateway) invoke(ctx context.Context, rt Route, req proto.Message) (proto.Message, error) {
+	info := &grpc.UnaryServerInfo{FullMethod: rt.FullMethod}
+
+	handler := func(ctx context.Context, req any) (any, error) {
+		return rt.call(ctx, req.(proto.Message))
+	}
+
+	for i := len(g.interceptors) - 1; i >= 0; i-- {
+		interceptor, next := g.interceptors[i], handler
+		handler = func(ctx context.Context, req any) (any, error) {
+			return interceptor(ctx, req, info, next)
+		}
+	}
+
+	resp, err := handler(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.(proto.Message), nil
+}
+
+func incomingHeader(key string) (string, bool) {
+	if textproto.CanonicalMIMEHeaderKey(key) == requestIDHeader {
+		return strings.ToLower(requestIDHeader), true
+	}
+
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+func outgoingHeader(key string) (string, bool) {
+	if textproto.CanonicalMIMEHeaderKey(key) == requestIDHeader {
+		return requestIDHeader, true
+	}
+
+	return runtime.MetadataHeaderPrefix + key, true
+}
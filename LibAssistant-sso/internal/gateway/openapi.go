@@ -0,0 +1,187 @@
+package gateway
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// openAPI generates an OpenAPI 3 document for the registered routes from the
+// request and response message descriptors, using the JSON mapping the gateway speaks.
+func (g *Gateway) openAPI(title string) []byte {
+	paths := make(map[string]map[string]any)
+	schemas := make(map[string]any)
+
+	for _, rt := range g.routes {
+		in, out := rt.input.Descriptor(), rt.output.Descriptor()
+		addSchema(schemas, in)
+		addSchema(schemas, out)
+
+		op := map[string]any{
+			"operationId": operationID(rt.FullMethod),
+			"summary":     rt.Summary,
+			"responses": map[string]any{
+				"200": map[string]any{
+					"description": "OK",
+					"content":     jsonContent(ref(out)),
+				},
+				"default": map[string]any{
+					"description": "gRPC status mapped to an HTTP status; validation errors carry google.rpc.BadRequest details",
+					"content":     jsonContent(map[string]any{"$ref": "#/components/schemas/google.rpc.Status"}),
+				},
+			},
+		}
+
+		var params []any
+		for _, name := range pathParams(rt.Path) {
+			params = append(params, map[string]any{
+				"name":     name,
+				"in":       "path",
+				"required": true,
+				"schema":   fieldSchema(in.Fields().ByName(protoreflect.Name(name))),
+			})
+		}
+		if len(params) > 0 {
+			op["parameters"] = params
+		}
+
+		if rt.Method != http.MethodGet {
+			op["requestBody"] = map[string]any{
+				"required": true,
+				"content":  jsonContent(ref(in)),
+			}
+		}
+
+		if paths[rt.Path] == nil {
+			paths[rt.Path] = make(map[string]any)
+		}
+		paths[rt.Path][strings.ToLower(rt.Method)] = op
+	}
+
+	schemas["google.rpc.Status"] = map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"code":    map[string]any{"type": "integer", "format": "int32"},
+			"message": map[string]any{"type": "string"},
+			"details": map[string]any{
+				"type":  "array",
+				"items": map[string]any{"type": "object", "additionalProperties": true},
+			},
+		},
+	}
+
+	doc := map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":   title,
+			"version": "v1",
+		},
+		"paths": paths,
+		"components": map[string]any{
+			"schemas": schemas,
+		},
+	}
+
+	b, _ := json.MarshalIndent(doc, "", "  ")
+
+	return b
+}
+
+func addSchema(schemas map[string]any, md protoreflect.MessageDescriptor) {
+	name := string(md.FullName())
+	if _, ok := schemas[name]; ok {
+		return
+	}
+
+	props := make(map[string]any)
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+
+		if fd.Kind() == protoreflect.MessageKind {
+			addSchema(schemas, fd.Message())
+		}
+
+		props[string(fd.Name())] = fieldSchema(fd)
+	}
+
+	schemas[name] = map[string]any{
+		"type":       "object",
+		"properties": props,
+	}
+}
+
+// fieldSchema follows the protojson mapping, e.g. 64-bit integers are strings.
+func fieldSchema(fd protoreflect.FieldDescriptor) map[string]any {
+	var s map[string]any
+
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		s = map[string]any{"type": "boolean"}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		s = map[string]any{"type": "integer", "format": "int32"}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		s = map[string]any{"type": "integer", "format": "int64"}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		s = map[string]any{"type": "string", "format": "int64"}
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		s = map[string]any{"type": "string", "format": "uint64"}
+	case protoreflect.FloatKind:
+		s = map[string]any{"type": "number", "format": "float"}
+	case protoreflect.DoubleKind:
+		s = map[string]any{"type": "number", "format": "double"}
+	case protoreflect.BytesKind:
+		s = map[string]any{"type": "string", "format": "byte"}
+	case protoreflect.EnumKind:
+		var values []string
+		ev := fd.Enum().Values()
+		for i := 0; i < ev.Len(); i++ {
+			values = append(values, string(ev.Get(i).Name()))
+		}
+		s = map[string]any{"type": "string", "enum": values}
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		s = ref(fd.Message())
+	default:
+		s = map[string]any{"type": "string"}
+	}
+
+	if fd.IsList() {
+		return map[string]any{"type": "array", "items": s}
+	}
+
+	return s
+}
+
+func ref(md protoreflect.MessageDescriptor) map[string]any {
+	return map[string]any{"$ref": "#/components/schemas/" + string(md.FullName())}
+}
+
+func jsonContent(schema map[string]any) map[string]any {
+	return map[string]any{
+		"application/json": map[string]any{"schema": schema},
+	}
+}
+
+// operationID turns "/auth.Auth/Login" into "Auth_Login".
+func operationID(fullMethod string) string {
+	service, method, _ := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	if i := strings.LastIndex(service, "."); i >= 0 {
+		service = service[i+1:]
+	}
+
+	return service + "_" + method
+}
+
+func pathParams(path string) []string {
+	var params []string
+
+	for _, segment := range strings.Split(path, "/") {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			params = append(params, strings.Trim(segment, "{}"))
+		}
+	}
+
+	return params
+}
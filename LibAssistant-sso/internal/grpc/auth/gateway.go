@@ -0,0 +1,24 @@
+package authgrpc
+
+import (
+	"LibAssistant_sso/internal/gateway"
+	"net/http"
+
+	ssov1 "github.com/MKode312/protos/gen/go/LibAssistant/sso"
+)
+
+// GatewayRoutes mirrors the Auth RPCs over HTTP/JSON.
+func GatewayRoutes(auth Auth) []gateway.Route {
+	srv := &serverAPI{auth: auth}
+
+	return []gateway.Route{
+		gateway.Unary(http.MethodPost, "/v1/auth/register", ssov1.Auth_Register_FullMethodName,
+			"Register a new user", srv.Register),
+		gateway.Unary(http.MethodPost, "/v1/auth/register-admin", ssov1.Auth_RegisterAsAdmin_FullMethodName,
+			"Register a new admin with the admin secret", srv.RegisterAsAdmin),
+		gateway.Unary(http.MethodPost, "/v1/auth/login", ssov1.Auth_Login_FullMethodName,
+			"Log in and get an access token", srv.Login),
+		gateway.Unary(http.MethodGet, "/v1/auth/users/{user_id}/is-admin", ssov1.Auth_IsAdmin_FullMethodName,
+			"Check whether a user is an admin", srv.IsAdmin),
+	}
+}
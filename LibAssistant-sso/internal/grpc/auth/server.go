@@ -1,6 +1,7 @@
 package authgrpc
 
 import (
+	"LibAssistant_sso/internal/grpc/validation"
 	"LibAssistant_sso/internal/services/auth"
 	"context"
 	"errors"
@@ -28,15 +29,20 @@ func Register(gRPC *grpc.Server, auth Auth) {
 }
 
 func (s *serverAPI) Login(ctx context.Context, req *ssov1.LoginRequest) (*ssov1.LoginResponse, error) {
-	if err := validateLogin(req); err != nil {
+	email := validation.NormalizeEmail(req.GetEmail())
+
+	if err := validateLogin(email, req); err != nil {
 		return nil, err
 	}
 
-	token, err := s.auth.Login(ctx, req.GetEmail(), req.GetPassword())
+	token, err := s.auth.Login(ctx, email, req.GetPassword())
 	if err != nil {
 		if errors.Is(err, auth.ErrInvalidCredentials) {
 			return nil, status.Error(codes.InvalidArgument, "invalid email or password")
 		}
+		if errors.Is(err, auth.ErrUserDisabled) {
+			return nil, status.Error(codes.PermissionDenied, "account is disabled")
+		}
 		return nil, status.Error(codes.Internal, "internal error")
 	}
 
@@ -47,11 +53,13 @@ func (s *serverAPI) Login(ctx context.Context, req *ssov1.LoginRequest) (*ssov1.
 }
 
 func (s *serverAPI) Register(ctx context.Context, req *ssov1.RegisterRequest) (*ssov1.RegisterResponse, error) {
-	if err := validateRegister(req); err != nil {
+	email := validation.NormalizeEmail(req.GetEmail())
+
+	if err := validateRegister(email, req); err != nil {
 		return nil, err
 	}
 
-	userID, err := s.auth.RegisterNewUser(ctx, req.GetEmail(), req.GetPassword())
+	userID, err := s.auth.RegisterNewUser(ctx, email, req.GetPassword())
 	if err != nil {
 		if errors.Is(err, auth.ErrUserExists) {
 			return nil, status.Error(codes.AlreadyExists, "user already exists")
@@ -70,11 +78,13 @@ func (s *serverAPI) Register(ctx context.Context, req *ssov1.RegisterRequest) (*
 }
 
 func (s *serverAPI) RegisterAsAdmin(ctx context.Context, req *ssov1.RegisterAsAdminRequest) (*ssov1.RegisterAsAdminResponse, error) {
-	if err := validateRegisterAsAdmin(req); err != nil {
+	email := validation.NormalizeEmail(req.GetEmail())
+
+	if err := validateRegisterAsAdmin(email, req); err != nil {
 		return nil, err
 	}
 
-	userID, err := s.auth.RegisterNewAdmin(ctx, req.GetEmail(), req.GetPassword(), req.GetAdminSecret())
+	userID, err := s.auth.RegisterNewAdmin(ctx, email, req.GetPassword(), req.GetAdminSecret())
 	if err != nil {
 		if errors.Is(err, auth.ErrUserExists) {
 			return nil, status.Error(codes.AlreadyExists, "user already exists")
@@ -116,50 +126,53 @@ func (s *serverAPI) IsAdmin(ctx context.Context, req *ssov1.IsAdminRequest) (*ss
 	}, nil
 }
 
-func validateLogin(req *ssov1.LoginRequest) error {
-	if req.GetEmail() == "" {
-		return status.Error(codes.InvalidArgument, "email is required")
-	}
-
-	if req.GetPassword() == "" {
-		return status.Error(codes.InvalidArgument, "password is required")
-	}
-
-	return nil
+// Request schemas. Passwords are capped at 72 characters because bcrypt rejects
+// longer input; the minimum length only applies to new passwords.
+type loginSchema struct {
+	Email    string `json:"email" validate:"required,email,max=254"`
+	Password string `json:"password" validate:"required,max=72"`
 }
 
-func validateRegister(req *ssov1.RegisterRequest) error {
-	if req.GetEmail() == "" {
-		return status.Error(codes.InvalidArgument, "email is required")
-	}
-
-	if req.GetPassword() == "" {
-		return status.Error(codes.InvalidArgument, "password is required")
-	}
+type registerSchema struct {
+	Email    string `json:"email" validate:"required,email,max=254"`
+	Password string `json:"password" validate:"required,min=8,max=72"`
+}
 
-	return nil
+type registerAsAdminSchema struct {
+	Email       string `json:"email" validate:"required,email,max=254"`
+	Password    string `json:"password" validate:"required,min=8,max=72"`
+	AdminSecret string `json:"admin_secret" validate:"required"`
 }
 
-func validateRegisterAsAdmin(req *ssov1.RegisterAsAdminRequest) error {
-	if req.GetEmail() == "" {
-		return status.Error(codes.InvalidArgument, "email is required")
-	}
+type isAdminSchema struct {
+	UserID int64 `json:"user_id" validate:"required,gt=0"`
+}
 
-	if req.GetPassword() == "" {
-		return status.Error(codes.InvalidArgument, "password is required")
-	}
+// The validate functions take the normalized email instead of the one in req.
+func validateLogin(email string, req *ssov1.LoginRequest) error {
+	return validation.Struct(loginSchema{
+		Email:    email,
+		Password: req.GetPassword(),
+	})
+}
 
-	if req.GetAdminSecret() == "" {
-		return status.Error(codes.InvalidArgument, "admin secret key is required")
-	}
+func validateRegister(email string, req *ssov1.RegisterRequest) error {
+	return validation.Struct(registerSchema{
+		Email:    email,
+		Password: req.GetPassword(),
+	})
+}
 
-	return nil
+func validateRegisterAsAdmin(email string, req *ssov1.RegisterAsAdminRequest) error {
+	return validation.Struct(registerAsAdminSchema{
+		Email:       email,
+		Password:    req.GetPassword(),
+		AdminSecret: req.GetAdminSecret(),
+	})
 }
 
 func validateIsAdmin(req *ssov1.IsAdminRequest) error {
-	if req.GetUserId() == 0 {
-		return status.Error(codes.InvalidArgument, "secret admin password is required")
-	}
-
-	return nil
+	return validation.Struct(isAdminSchema{
+		UserID: req.GetUserId(),
+	})
 }
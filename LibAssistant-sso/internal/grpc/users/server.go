@@ -0,0 +1,436 @@
+package usersgrpc
+
+import (
+	"LibAssistant_sso/internal/domain/models"
+	"LibAssistant_sso/internal/grpc/validation"
+	"LibAssistant_sso/internal/services/auth"
+	"LibAssistant_sso/internal/services/events"
+	"LibAssistant_sso/internal/services/users"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+
+	usersv1 "LibAssistant_sso/protos/gen/go/LibAssistant/users"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type Users interface {
+	ImportUser(ctx context.Context, email string, name string, class string, role string, dryRun bool) (users.ImportedUser, error)
+	ExportUsers(ctx context.Context, includeDeleted bool) ([]models.User, error)
+	DeleteUser(ctx context.Context, userID int64) error
+	RestoreUser(ctx context.Context, userID int64) error
+	SetRole(ctx context.Context, userID int64, role string) error
+	SetDisabled(ctx context.Context, userID int64, disabled bool) error
+}
+
+type AdminChecker interface {
+	ParseToken(token string) (int64, error)
+	IsAdmin(ctx context.Context, userID int64) (bool, error)
+}
+
+type EventBroker interface {
+	Subscribe() *events.Subscription
+	Unsubscribe(sub *events.Subscription)
+	EventsAfter(ctx context.Context, cursor int64, limit int) ([]models.Event, error)
+	Done() <-chan struct{}
+}
+
+type serverAPI struct {
+	usersv1.UnimplementedUsersServer
+	users  Users
+	admins AdminChecker
+	events EventBroker
+}
+
+func Register(gRPC *grpc.Server, users Users, admins AdminChecker, events EventBroker) {
+	usersv1.RegisterUsersServer(gRPC, &serverAPI{users: users, admins: admins, events: events})
+}
+
+func (s *serverAPI) ImportUsers(stream grpc.ClientStreamingServer[usersv1.ImportUsersRequest, usersv1.ImportUsersResponse]) error {
+	ctx := stream.Context()
+
+	if err := s.requireAdmin(ctx); err != nil {
+		return err
+	}
+
+	var (
+		resp = &usersv1.ImportUsersResponse{}
+		seen = make(map[string]struct{})
+		row  int32
+	)
+
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return stream.SendAndClose(resp)
+		}
+		if err != nil {
+			return err
+		}
+
+		row++
+		if row == 1 {
+			resp.DryRun = req.GetDryRun()
+		}
+
+		user := req.GetUser()
+		email := validation.NormalizeEmail(user.GetEmail())
+
+		result := &usersv1.ImportResult{
+			Row:   row,
+			Email: email,
+		}
+
+		if !validation.Email(email) {
+			result.Status = usersv1.ImportStatus_IMPORT_STATUS_FAILED
+			result.Error = "invalid email"
+			resp.Results = append(resp.Results, result)
+
+			continue
+		}
+
+		if _, ok := seen[email]; ok {
+			result.Status = usersv1.ImportStatus_IMPORT_STATUS_FAILED
+			result.Error = "duplicate email in import"
+			resp.Results = append(resp.Results, result)
+
+			continue
+		}
+		seen[email] = struct{}{}
+
+		imported, err := s.users.ImportUser(ctx, email, strings.TrimSpace(user.GetName()), strings.TrimSpace(user.GetClass()), user.GetRole(), resp.DryRun)
+		switch {
+		case err != nil:
+			result.Status = usersv1.ImportStatus_IMPORT_STATUS_FAILED
+			result.Error = importErrorReason(err)
+		case resp.DryRun:
+			result.Status = usersv1.ImportStatus_IMPORT_STATUS_WOULD_CREATE
+		default:
+			result.Status = usersv1.ImportStatus_IMPORT_STATUS_CREATED
+			result.UserId = imported.UserID
+			result.InitialPassword = imported.InitialPassword
+		}
+
+		resp.Results = append(resp.Results, result)
+	}
+}
+
+func (s *serverAPI) ExportUsers(req *usersv1.ExportUsersRequest, stream grpc.ServerStreamingServer[usersv1.ExportUsersResponse]) error {
+	ctx := stream.Context()
+
+	if err := s.requireAdmin(ctx); err != nil {
+		return err
+	}
+
+	list, err := s.users.ExportUsers(ctx, req.GetIncludeDeleted())
+	if err != nil {
+		return status.Error(codes.Internal, "internal error")
+	}
+
+	for _, user := range list {
+		if err := stream.Send(&usersv1.ExportUsersResponse{User: toProtoUser(user)}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *serverAPI) DeleteUser(ctx context.Context, req *usersv1.DeleteUserRequest) (*usersv1.DeleteUserResponse, error) {
+	if err := s.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	if req.GetUserId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "user id is required")
+	}
+
+	if err := s.users.DeleteUser(ctx, req.GetUserId()); err != nil {
+		if errors.Is(err, users.ErrUserNotFound) {
+			return nil, status.Error(codes.NotFound, "user not found")
+		}
+
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return &usersv1.DeleteUserResponse{}, nil
+}
+
+func (s *serverAPI) RestoreUser(ctx context.Context, req *usersv1.RestoreUserRequest) (*usersv1.RestoreUserResponse, error) {
+	if err := s.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	if req.GetUserId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "user id is required")
+	}
+
+	if err := s.users.RestoreUser(ctx, req.GetUserId()); err != nil {
+		if errors.Is(err, users.ErrUserNotFound) {
+			return nil, status.Error(codes.NotFound, "deleted user not found")
+		}
+
+		if errors.Is(err, users.ErrUserExists) {
+			return nil, status.Error(codes.AlreadyExists, "the email has been registered again since deletion")
+		}
+
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return &usersv1.RestoreUserResponse{}, nil
+}
+
+// requireAdmin checks that the "authorization" metadata carries a valid token issued to an admin.
+func (s *serverAPI) requireAdmin(ctx context.Context) error {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	values := md.Get("authorization")
+	if len(values) == 0 || values[0] == "" {
+		return status.Error(codes.Unauthenticated, "authorization token is required")
+	}
+
+	uid, err := s.admins.ParseToken(strings.TrimPrefix(values[0], "Bearer "))
+	if err != nil {
+		return status.Error(codes.Unauthenticated, "invalid authorization token")
+	}
+
+	isAdmin, err := s.admins.IsAdmin(ctx, uid)
+	if err != nil {
+		if errors.Is(err, auth.ErrUserNotFound) {
+			return status.Error(codes.PermissionDenied, "admin rights required")
+		}
+
+		return status.Error(codes.Internal, "internal error")
+	}
+
+	if !isAdmin {
+		return status.Error(codes.PermissionDenied, "admin rights required")
+	}
+
+	return nil
+}
+
+func (s *serverAPI) SetUserRole(ctx context.Context, req *usersv1.SetUserRoleRequest) (*usersv1.SetUserRoleResponse, error) {
+	if err := s.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	if req.GetUserId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "user id is required")
+	}
+
+	if req.GetRole() == "" {
+		return nil, status.Error(codes.InvalidArgument, "role is required")
+	}
+
+	if err := s.users.SetRole(ctx, req.GetUserId(), req.GetRole()); err != nil {
+		if errors.Is(err, users.ErrInvalidRole) {
+			return nil, status.Error(codes.InvalidArgument, "invalid role, expected student or admin")
+		}
+
+		if errors.Is(err, users.ErrUserNotFound) {
+			return nil, status.Error(codes.NotFound, "user not found")
+		}
+
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return &usersv1.SetUserRoleResponse{}, nil
+}
+
+func (s *serverAPI) DisableUser(ctx context.Context, req *usersv1.DisableUserRequest) (*usersv1.DisableUserResponse, error) {
+	if err := s.setDisabled(ctx, req.GetUserId(), true); err != nil {
+		return nil, err
+	}
+
+	return &usersv1.DisableUserResponse{}, nil
+}
+
+func (s *serverAPI) EnableUser(ctx context.Context, req *usersv1.EnableUserRequest) (*usersv1.EnableUserResponse, error) {
+	if err := s.setDisabled(ctx, req.GetUserId(), false); err != nil {
+		return nil, err
+	}
+
+	return &usersv1.EnableUserResponse{}, nil
+}
+
+func (s *serverAPI) setDisabled(ctx context.Context, userID int64, disabled bool) error {
+	if err := s.requireAdmin(ctx); err != nil {
+		return err
+	}
+
+	if userID == 0 {
+		return status.Error(codes.InvalidArgument, "user id is required")
+	}
+
+	if err := s.users.SetDisabled(ctx, userID, disabled); err != nil {
+		if errors.Is(err, users.ErrUserNotFound) {
+			return status.Error(codes.NotFound, "user not found")
+		}
+
+		return status.Error(codes.Internal, "internal error")
+	}
+
+	return nil
+}
+
+func (s *serverAPI) WatchUserEvents(req *usersv1.WatchUserEventsRequest, stream grpc.ServerStreamingServer[usersv1.UserEvent]) error {
+	ctx := stream.Context()
+
+	if err := s.requireAdmin(ctx); err != nil {
+		return err
+	}
+
+	if req.GetCursor() < 0 {
+		return status.Error(codes.InvalidArgument, "cursor must not be negative")
+	}
+
+	w := &watcher{
+		stream: stream,
+		events: s.events,
+		cursor: req.GetCursor(),
+		types:  make(map[string]struct{}, len(req.GetTypes())),
+	}
+	for _, t := range req.GetTypes() {
+		w.types[t] = struct{}{}
+	}
+
+	// Subscribe before replaying, so events committed during the replay are not lost.
+	sub := s.events.Subscribe()
+	defer s.events.Unsubscribe(sub)
+
+	if err := w.replay(ctx); err != nil {
+		return err
+	}
+
+	caughtUp := false
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-s.events.Done():
+			return status.Errorf(codes.Unavailable, "server is shutting down, resume from cursor %d", w.cursor)
+		case <-sub.Dropped():
+			return status.Errorf(codes.ResourceExhausted, "consumer is too slow, resume from cursor %d", w.cursor)
+		case event := <-sub.Events():
+			if event.ID <= w.cursor {
+				continue
+			}
+
+			// The first live event may be ahead of what the replay saw,
+			// so fill the gap from storage once.
+			if !caughtUp {
+				caughtUp = true
+
+				if err := w.replay(ctx); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			if err := w.send(event); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+const replayBatchSize = 500
+
+type watcher struct {
+	stream grpc.ServerStreamingServer[usersv1.UserEvent]
+	events EventBroker
+	cursor int64
+	types  map[string]struct{}
+}
+
+// replay sends every stored event after the cursor.
+func (w *watcher) replay(ctx context.Context) error {
+	for {
+		list, err := w.events.EventsAfter(ctx, w.cursor, replayBatchSize)
+		if err != nil {
+			return status.Error(codes.Internal, "internal error")
+		}
+
+		for _, event := range list {
+			if err := w.send(event); err != nil {
+				return err
+			}
+		}
+
+		if len(list) < replayBatchSize {
+			return nil
+		}
+	}
+}
+
+func (w *watcher) send(event models.Event) error {
+	w.cursor = event.ID
+
+	if len(w.types) > 0 {
+		if _, ok := w.types[event.Type]; !ok {
+			return nil
+		}
+	}
+
+	var payload models.UserEventPayload
+	if err := json.Unmarshal(event.Payload, &payload); err != nil {
+		return status.Error(codes.Internal, "internal error")
+	}
+
+	return w.stream.Send(&usersv1.UserEvent{
+		Cursor:     event.ID,
+		Id:         event.EventID,
+		Type:       event.Type,
+		UserId:     event.UserID,
+		Email:      payload.Email,
+		Role:       payload.Role,
+		OccurredAt: timestamppb.New(payload.OccurredAt),
+	})
+}
+
+func toProtoUser(user models.User) *usersv1.User {
+	u := &usersv1.User{
+		Id:        user.ID,
+		Email:     user.Email,
+		Name:      user.Name,
+		Class:     user.Class,
+		Role:      user.Role(),
+		CreatedAt: timestamppb.New(user.CreatedAt),
+		UpdatedAt: timestamppb.New(user.UpdatedAt),
+	}
+
+	if user.LastLoginAt != nil {
+		u.LastLoginAt = timestamppb.New(*user.LastLoginAt)
+	}
+
+	if user.DisabledAt != nil {
+		u.DisabledAt = timestamppb.New(*user.DisabledAt)
+	}
+
+	if user.DeletedAt != nil {
+		u.DeletedAt = timestamppb.New(*user.DeletedAt)
+	}
+
+	return u
+}
+
+func importErrorReason(err error) string {
+	switch {
+	case errors.Is(err, users.ErrInvalidRole):
+		return "invalid role, expected student or admin"
+	case errors.Is(err, users.ErrUserExists):
+		return "user already exists"
+	default:
+		return "internal error"
+	}
+}
@@ -0,0 +1,104 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var validate = newValidator()
+
+func newValidator() *validator.Validate {
+	v := validator.New(validator.WithRequiredStructEnabled())
+
+	// Violations are reported with the proto field names clients send.
+	v.RegisterTagNameFunc(func(f reflect.StructField) string {
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			return f.Name
+		}
+
+		return name
+	})
+
+	return v
+}
+
+// NormalizeEmail returns email the way it is validated, stored and looked up:
+// trimmed and lower-cased, so addresses differing only in case are one account.
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// Email reports whether email passes the email rule of the request schemas.
+func Email(email string) bool {
+	return validate.Var(email, "required,email,max=254") == nil
+}
+
+// Struct checks schema against its validate tags. A failed check is returned as an
+// InvalidArgument status carrying an errdetails.BadRequest with one violation per
+// field; the status message joins the descriptions so plain clients can read it too.
+func Struct(schema any) error {
+	err := validate.Struct(schema)
+	if err == nil {
+		return nil
+	}
+
+	var verrs validator.ValidationErrors
+	if !errors.As(err, &verrs) {
+		return status.Error(codes.Internal, "internal error")
+	}
+
+	br := &errdetails.BadRequest{}
+	msgs := make([]string, 0, len(verrs))
+
+	for _, fe := range verrs {
+		desc := describe(fe)
+
+		br.FieldViolations = append(br.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       fe.Field(),
+			Description: desc,
+		})
+		msgs = append(msgs, desc)
+	}
+
+	st, err := status.New(codes.InvalidArgument, strings.Join(msgs, "; ")).WithDetails(br)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, strings.Join(msgs, "; "))
+	}
+
+	return st.Err()
+}
+
+func describe(fe validator.FieldError) string {
+	field := strings.ReplaceAll(fe.Field(), "_", " ")
+
+	switch fe.Tag() {
+	case "required":
+		return fmt.Sprintf("%s is required", field)
+	case "email":
+		return fmt.Sprintf("%s must be a valid email address", field)
+	case "min":
+		if fe.Kind() == reflect.String {
+			return fmt.Sprintf("%s must be at least %s characters long", field, fe.Param())
+		}
+
+		return fmt.Sprintf("%s must be at least %s", field, fe.Param())
+	case "max":
+		if fe.Kind() == reflect.String {
+			return fmt.Sprintf("%s must be at most %s characters long", field, fe.Param())
+		}
+
+		return fmt.Sprintf("%s must be at most %s", field, fe.Param())
+	case "gt":
+		return fmt.Sprintf("%s must be greater than %s", field, fe.Param())
+	default:
+		return fmt.Sprintf("%s is not valid", field)
+	}
+}
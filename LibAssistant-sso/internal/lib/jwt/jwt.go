@@ -3,31 +3,50 @@ package jwt
 import (
 	"LibAssistant_sso/internal/domain/models"
 	"errors"
-	"os"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
-
-	_ "github.com/joho/godotenv/autoload"
 )
 
-func NewToken(user models.User, duration time.Duration) (string, error) {
+func NewToken(user models.User, duration time.Duration, secret string) (string, error) {
 	token := jwt.New(jwt.SigningMethodHS256)
 
 	claims := token.Claims.(jwt.MapClaims)
 	claims["uid"] = user.ID
 	claims["email"] = user.Email
+	claims["roles"] = []string{user.Role()}
+	claims["iat"] = time.Now().Unix()
 	claims["exp"] = time.Now().Add(duration).Unix()
 
-	app_secret, ok := os.LookupEnv("APP_SECRET")
-	if !ok {
-		return "", errors.New("app_secret not found")
-	}
-
-	tokenString, err := token.SignedString([]byte(app_secret))
+	tokenString, err := token.SignedString([]byte(secret))
 	if err != nil {
 		return "", err
 	}
 
 	return tokenString, nil
 }
+
+// ParseToken validates a token issued by NewToken and returns the user id it was issued for.
+func ParseToken(tokenString string, secret string) (int64, error) {
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errors.New("unexpected signing method")
+		}
+		return []byte(secret), nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return 0, errors.New("unexpected claims type")
+	}
+
+	uid, ok := claims["uid"].(float64)
+	if !ok {
+		return 0, errors.New("uid claim is missing")
+	}
+
+	return int64(uid), nil
+}
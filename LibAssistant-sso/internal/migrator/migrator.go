@@ -0,0 +1,115 @@
+package migrator
+
+import (
+	"LibAssistant_sso/internal/lib/logger/sl"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/golang-migrate/migrate/v4"
+	_ "github.com/golang-migrate/migrate/v4/database/postgres"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// SchemaVersion is the migration version this binary was built against.
+// Bump it together with every new file in the migrations directory.
+const SchemaVersion uint = 10
+
+// AdvisoryLockID identifies the migration lock shared by all SSO replicas.
+const AdvisoryLockID int64 = 44043
+
+// pgUndefinedTable is returned by postgres when schema_migrations does not exist yet.
+const pgUndefinedTable = "42P01"
+
+var (
+	ErrSchemaOutdated = errors.New("database schema is older than expected")
+	ErrSchemaDirty    = errors.New("database schema is dirty")
+)
+
+// Up applies all pending migrations from migrationsPath.
+// Only one instance migrates at a time: the others block on a postgres
+// advisory lock until the first one finishes, then find nothing to apply.
+func Up(ctx context.Context, log *slog.Logger, dsn string, migrationsPath string) error {
+	const op = "migrator.Up"
+
+	log = log.With(slog.String("op", op))
+
+	conn, err := pgx.Connect(ctx, dsn)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer conn.Close(context.Background())
+
+	log.Info("waiting for migration lock")
+
+	if _, err := conn.Exec(ctx, "SELECT pg_advisory_lock($1)", AdvisoryLockID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() {
+		if _, err := conn.Exec(context.Background(), "SELECT pg_advisory_unlock($1)", AdvisoryLockID); err != nil {
+			log.Error("failed to release migration lock", sl.Err(err))
+		}
+	}()
+
+	log.Info("migration lock acquired")
+
+	m, err := migrate.New("file://"+migrationsPath, dsn)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer m.Close()
+
+	if err := m.Up(); err != nil {
+		if errors.Is(err, migrate.ErrNoChange) {
+			log.Info("no migrations to apply")
+
+			return nil
+		}
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("migrations applied successfully")
+
+	return nil
+}
+
+// CheckVersion makes sure the database schema is not older than SchemaVersion
+// and that the last migration did not fail halfway.
+func CheckVersion(ctx context.Context, dsn string) error {
+	const op = "migrator.CheckVersion"
+
+	conn, err := pgx.Connect(ctx, dsn)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer conn.Close(context.Background())
+
+	var (
+		version uint
+		dirty   bool
+	)
+
+	err = conn.QueryRow(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&version, &dirty)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.Is(err, pgx.ErrNoRows) || (errors.As(err, &pgErr) && pgErr.Code == pgUndefinedTable) {
+			return fmt.Errorf("%s: %w: no migrations applied, expected version %d", op, ErrSchemaOutdated, SchemaVersion)
+		}
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if dirty {
+		return fmt.Errorf("%s: %w: version %d", op, ErrSchemaDirty, version)
+	}
+
+	if version < SchemaVersion {
+		return fmt.Errorf("%s: %w: got version %d, expected %d", op, ErrSchemaOutdated, version, SchemaVersion)
+	}
+
+	return nil
+}
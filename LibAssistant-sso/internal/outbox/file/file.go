@@ -0,0 +1,53 @@
+package file
+
+import (
+	"LibAssistant_sso/internal/domain/models"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// Sink appends every event as a JSON line to a local file.
+type Sink struct {
+	mu sync.Mutex
+	f  *os.File
+}
+
+func New(path string) (*Sink, error) {
+	const op = "outbox.file.New"
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &Sink{f: f}, nil
+}
+
+func (s *Sink) Publish(_ context.Context, event models.Event) error {
+	const op = "outbox.file.Publish"
+
+	line, err := json.Marshal(event.Envelope())
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := s.f.Sync(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Sink) Close() error {
+	return s.f.Close()
+}
@@ -0,0 +1,58 @@
+package webhook
+
+import (
+	"LibAssistant_sso/internal/domain/models"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Sink posts every event as JSON to a single URL.
+// Receivers should use the Idempotency-Key header to drop redeliveries.
+type Sink struct {
+	url    string
+	client *http.Client
+}
+
+func New(url string, timeout time.Duration) *Sink {
+	return &Sink{
+		url:    url,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+func (s *Sink) Publish(ctx context.Context, event models.Event) error {
+	const op = "outbox.webhook.Publish"
+
+	body, err := json.Marshal(event.Envelope())
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Idempotency-Key", event.EventID)
+	req.Header.Set("X-Event-Type", event.Type)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer resp.Body.Close()
+
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%s: unexpected status %d", op, resp.StatusCode)
+	}
+
+	return nil
+}
@@ -4,28 +4,40 @@ import (
 	"LibAssistant_sso/internal/domain/models"
 	"LibAssistant_sso/internal/lib/jwt"
 	"LibAssistant_sso/internal/lib/logger/sl"
+	"LibAssistant_sso/internal/metrics"
 	"LibAssistant_sso/internal/storage"
 	"context"
 	"errors"
 	"fmt"
 	"log/slog"
-	"os"
+	"sync/atomic"
 	"time"
 
-	_ "github.com/joho/godotenv/autoload"
+	"go.opentelemetry.io/otel"
 	"golang.org/x/crypto/bcrypt"
 )
 
+var tracer = otel.Tracer("LibAssistant_sso/internal/services/auth")
+
 type Auth struct {
 	log          *slog.Logger
 	userSaver    UserSaver
 	userProvider UserProvider
-	tokenTTL     time.Duration
+	tokenTTL     atomic.Int64
+	appSecret    string
+	adminSecret  string
+	metrics      Metrics
 }
 
 type UserSaver interface {
 	SaveUser(ctx context.Context, email string, passHash []byte) (uid int64, err error)
 	SaveAdmin(ctx context.Context, email string, passHash []byte) (uid int64, err error)
+	UpdateLastLogin(ctx context.Context, userID int64) error
+}
+
+type Metrics interface {
+	Login(result string)
+	Registration(role string)
 }
 
 type UserProvider interface {
@@ -38,65 +50,100 @@ var (
 	ErrWrongAdminSecret = errors.New("the provided admin secret key is wrong")
 	ErrUserExists = errors.New("user already exists")
 	ErrUserNotFound = errors.New("user not found")
+	ErrUserDisabled = errors.New("user is disabled")
 )
 
-func New(log *slog.Logger, userSaver UserSaver, userProvider UserProvider, tokenTTL time.Duration) *Auth {
-	return &Auth{
+func New(log *slog.Logger, userSaver UserSaver, userProvider UserProvider, tokenTTL time.Duration, appSecret string, adminSecret string, metrics Metrics) *Auth {
+	a := &Auth{
 		userSaver:    userSaver,
 		userProvider: userProvider,
 		log:          log,
-		tokenTTL:     tokenTTL,
+		appSecret:    appSecret,
+		adminSecret:  adminSecret,
+		metrics:      metrics,
 	}
+
+	a.SetTokenTTL(tokenTTL)
+
+	return a
+}
+
+// SetTokenTTL changes the lifetime of tokens issued from now on; safe for concurrent use.
+func (a *Auth) SetTokenTTL(ttl time.Duration) {
+	a.tokenTTL.Store(int64(ttl))
 }
 
 func (a *Auth) Login(ctx context.Context, email string, password string) (string, error) {
 	const op = "auth.Login"
 
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
 	log := a.log.With(slog.String("op", op))
 
-	log.Info("attempting to login user")
+	log.InfoContext(ctx, "attempting to login user")
 
 	user, err := a.userProvider.User(ctx, email)
 	if err != nil {
 		if errors.Is(err, storage.ErrUserNotFound) {
-			log.Warn("user not found", sl.Err(err))
+			log.WarnContext(ctx, "user not found", sl.Err(err))
+			a.metrics.Login(metrics.LoginInvalidCredentials)
 
 			return "", fmt.Errorf("%s: %w", op, ErrInvalidCredentials)
 		}
 
-		log.Error("failed to get user", sl.Err(err))
+		log.ErrorContext(ctx, "failed to get user", sl.Err(err))
+		a.metrics.Login(metrics.LoginError)
 
 		return "", fmt.Errorf("%s: %w", op, err)
 	}
 
 	if err := bcrypt.CompareHashAndPassword(user.PassHash, []byte(password)); err != nil {
-		log.Info("invalid credentials", sl.Err(err))
+		log.InfoContext(ctx, "invalid credentials", sl.Err(err))
+		a.metrics.Login(metrics.LoginInvalidCredentials)
 
 		return "", fmt.Errorf("%s: %w", op, ErrInvalidCredentials)
 	}
 
-	log.Info("user logged in successfully")
+	if user.DisabledAt != nil {
+		log.WarnContext(ctx, "disabled user tried to log in")
+		a.metrics.Login(metrics.LoginDisabled)
+
+		return "", fmt.Errorf("%s: %w", op, ErrUserDisabled)
+	}
+
+	if err := a.userSaver.UpdateLastLogin(ctx, user.ID); err != nil {
+		log.ErrorContext(ctx, "failed to update last login time", sl.Err(err))
+	}
+
+	log.InfoContext(ctx, "user logged in successfully")
 
-	token, err := jwt.NewToken(user, a.tokenTTL)
+	token, err := jwt.NewToken(user, time.Duration(a.tokenTTL.Load()), a.appSecret)
 	if err != nil {
-		log.Error("failed to generate token", sl.Err(err))
+		log.ErrorContext(ctx, "failed to generate token", sl.Err(err))
+		a.metrics.Login(metrics.LoginError)
 
 		return "", fmt.Errorf("%s: %w", op, err)
 	}
 
+	a.metrics.Login(metrics.LoginSuccess)
+
 	return token, nil
 }
 
 func (a *Auth) RegisterNewUser(ctx context.Context, email string, password string) (int64, error) {
 	const op = "auth.RegisterNewUser"
 
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
 	log := a.log.With(slog.String("op", op))
 
-	log.Info("registering user")
+	log.InfoContext(ctx, "registering user")
 
 	passHash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
 	if err != nil {
-		log.Error("failed to generate password hash", sl.Err(err))
+		log.ErrorContext(ctx, "failed to generate password hash", sl.Err(err))
 
 		return 0, fmt.Errorf("%s: %w", op, err)
 	}
@@ -104,16 +151,17 @@ func (a *Auth) RegisterNewUser(ctx context.Context, email string, password strin
 	id, err := a.userSaver.SaveUser(ctx, email, passHash)
 	if err != nil {
 		if errors.Is(err, storage.ErrUserExists) {
-			log.Error("user already exists", sl.Err(err))
+			log.ErrorContext(ctx, "user already exists", sl.Err(err))
 
 			return 0, fmt.Errorf("%s: %w", op, ErrUserExists)
 		}
-		log.Error("failed to save user", sl.Err(err))
+		log.ErrorContext(ctx, "failed to save user", sl.Err(err))
 
 		return 0, fmt.Errorf("%s: %w", op, err)
 	}
 
-	log.Info("user registered")
+	log.InfoContext(ctx, "user registered")
+	a.metrics.Registration(models.RoleStudent)
 
 	return id, nil
 }
@@ -121,26 +169,22 @@ func (a *Auth) RegisterNewUser(ctx context.Context, email string, password strin
 func (a *Auth) RegisterNewAdmin(ctx context.Context, email string, password string, admin_secret string) (int64, error) {
 	const op = "auth.RegisterNewAdmin"
 
-	log := a.log.With(slog.String("op", op))
-
-	log.Info("registering admin")
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
 
-	adminPswd, ok := os.LookupEnv("ADMIN_SECRET")
-	if !ok {
-		log.Error("failed to find admin_secret")
+	log := a.log.With(slog.String("op", op))
 
-		return 0, fmt.Errorf("%s: %w", op, errors.New("admin_key not found"))
-	}
+	log.InfoContext(ctx, "registering admin")
 
-	if admin_secret != adminPswd {
-		log.Error("wrong admin secret key")
+	if admin_secret != a.adminSecret {
+		log.ErrorContext(ctx, "wrong admin secret key")
 
 		return 0, fmt.Errorf("%s: %w", op, ErrWrongAdminSecret)
 	}
 
 	passHash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
 	if err != nil {
-		log.Error("failed to generate password hash", sl.Err(err))
+		log.ErrorContext(ctx, "failed to generate password hash", sl.Err(err))
 
 		return 0, fmt.Errorf("%s: %w", op, err)
 	}
@@ -148,36 +192,45 @@ func (a *Auth) RegisterNewAdmin(ctx context.Context, email string, password stri
 	id, err := a.userSaver.SaveAdmin(ctx, email, passHash)
 	if err != nil {
 		if errors.Is(err, storage.ErrUserExists) {
-			log.Error("user already exists", sl.Err(err))
+			log.ErrorContext(ctx, "user already exists", sl.Err(err))
 			return 0, fmt.Errorf("%s: %w", op, ErrUserExists)
 		}
-		log.Error("failed to save admin", sl.Err(err))
+		log.ErrorContext(ctx, "failed to save admin", sl.Err(err))
 
 		return 0, fmt.Errorf("%s: %w", op, err)
 	}
 
-	log.Info("admin registered")
+	log.InfoContext(ctx, "admin registered")
+	a.metrics.Registration(models.RoleAdmin)
 
 	return id, nil
 }
 
+// ParseToken validates an access token issued by Login and returns its user id.
+func (a *Auth) ParseToken(token string) (int64, error) {
+	return jwt.ParseToken(token, a.appSecret)
+}
+
 func (a *Auth) IsAdmin(ctx context.Context, userID int64) (bool, error) {
 	const op = "auth.IsAdmin"
 
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
 	log := a.log.With(slog.String("op", op))
 
-	log.Info("checking if user is admin")
+	log.InfoContext(ctx, "checking if user is admin")
 
 	isAdmin, err := a.userProvider.IsAdmin(ctx, userID)
 	if err != nil {
 		if errors.Is(err, storage.ErrUserNotFound) {
-			log.Error("user not found", sl.Err(err))
+			log.ErrorContext(ctx, "user not found", sl.Err(err))
 			return false, fmt.Errorf("%s: %w", op, ErrUserNotFound)
 		}
 		return false, fmt.Errorf("%s: %w", op, err)
 	}
 
-	log.Info("checked if user is admin")
+	log.InfoContext(ctx, "checked if user is admin")
 
 	return isAdmin, nil
 }
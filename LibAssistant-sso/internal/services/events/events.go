@@ -0,0 +1,184 @@
+package events
+
+import (
+	"LibAssistant_sso/internal/domain/models"
+	"LibAssistant_sso/internal/lib/logger/sl"
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+const pollBatchSize = 500
+
+type EventProvider interface {
+	EventsAfter(ctx context.Context, cursor int64, limit int) ([]models.Event, error)
+	LastEventID(ctx context.Context) (int64, error)
+}
+
+// Broker polls the outbox and fans new events out to live subscribers.
+// A subscriber that does not keep up is dropped instead of blocking the others;
+// it is expected to reconnect and resume from the last cursor it processed.
+type Broker struct {
+	log          *slog.Logger
+	provider     EventProvider
+	pollInterval time.Duration
+	bufferSize   int
+
+	mu   sync.Mutex
+	subs map[*Subscription]struct{}
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+type Subscription struct {
+	events  chan models.Event
+	dropped chan struct{}
+}
+
+// Events delivers events committed after the subscription was created.
+func (s *Subscription) Events() <-chan models.Event {
+	return s.events
+}
+
+// Dropped is closed when the subscriber fell too far behind and was disconnected.
+func (s *Subscription) Dropped() <-chan struct{} {
+	return s.dropped
+}
+
+func New(log *slog.Logger, provider EventProvider, pollInterval time.Duration, bufferSize int) *Broker {
+	return &Broker{
+		log:          log,
+		provider:     provider,
+		pollInterval: pollInterval,
+		bufferSize:   bufferSize,
+		subs:         make(map[*Subscription]struct{}),
+		stop:         make(chan struct{}),
+		done:         make(chan struct{}),
+	}
+}
+
+// Run polls the outbox until Stop is called.
+func (b *Broker) Run() {
+	const op = "events.Run"
+
+	log := b.log.With(slog.String("op", op))
+
+	defer close(b.done)
+
+	log.Info("event broker started")
+
+	ticker := time.NewTicker(b.pollInterval)
+	defer ticker.Stop()
+
+	// Live subscribers only get events committed after the broker started;
+	// older ones are replayed from storage by the subscriber itself.
+	cursor := int64(-1)
+
+	for {
+		select {
+		case <-ticker.C:
+			if cursor < 0 {
+				last, err := b.provider.LastEventID(context.Background())
+				if err != nil {
+					log.Error("failed to get last event id", sl.Err(err))
+
+					continue
+				}
+				cursor = last
+			}
+
+			cursor = b.poll(log, cursor)
+		case <-b.stop:
+			return
+		}
+	}
+}
+
+func (b *Broker) poll(log *slog.Logger, cursor int64) int64 {
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), b.pollInterval+time.Minute)
+		events, err := b.provider.EventsAfter(ctx, cursor, pollBatchSize)
+		cancel()
+
+		if err != nil {
+			log.Error("failed to poll events", sl.Err(err))
+
+			return cursor
+		}
+
+		for _, event := range events {
+			b.publish(event)
+			cursor = event.ID
+		}
+
+		if len(events) < pollBatchSize {
+			return cursor
+		}
+	}
+}
+
+func (b *Broker) publish(event models.Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for sub := range b.subs {
+		select {
+		case sub.events <- event:
+		default:
+			b.log.Warn("dropping slow event subscriber", slog.Int64("cursor", event.ID))
+
+			close(sub.dropped)
+			delete(b.subs, sub)
+		}
+	}
+}
+
+func (b *Broker) Stop() {
+	const op = "events.Stop"
+
+	b.log.With(slog.String("op", op)).Info("stopping event broker")
+
+	close(b.stop)
+	<-b.done
+}
+
+// Done is closed when the broker is stopping, so live streams can end before the server stops.
+func (b *Broker) Done() <-chan struct{} {
+	return b.stop
+}
+
+// Subscribe registers a live subscriber. Call it before replaying history,
+// so no event falls between the replay and the live stream.
+func (b *Broker) Subscribe() *Subscription {
+	sub := &Subscription{
+		events:  make(chan models.Event, b.bufferSize),
+		dropped: make(chan struct{}),
+	}
+
+	b.mu.Lock()
+	b.subs[sub] = struct{}{}
+	b.mu.Unlock()
+
+	return sub
+}
+
+func (b *Broker) Unsubscribe(sub *Subscription) {
+	b.mu.Lock()
+	delete(b.subs, sub)
+	b.mu.Unlock()
+}
+
+// EventsAfter returns stored events after cursor, used to replay history to a resuming subscriber.
+func (b *Broker) EventsAfter(ctx context.Context, cursor int64, limit int) ([]models.Event, error) {
+	const op = "events.EventsAfter"
+
+	events, err := b.provider.EventsAfter(ctx, cursor, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return events, nil
+}
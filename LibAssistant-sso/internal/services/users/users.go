@@ -0,0 +1,300 @@
+package users
+
+import (
+	"LibAssistant_sso/internal/domain/models"
+	"LibAssistant_sso/internal/lib/logger/sl"
+	"LibAssistant_sso/internal/storage"
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	initialPasswordLen     = 12
+	initialPasswordCharset = "abcdefghijkmnpqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+)
+
+type Users struct {
+	log          *slog.Logger
+	userSaver    UserSaver
+	userProvider UserProvider
+}
+
+type UserSaver interface {
+	SaveImportedUser(ctx context.Context, user models.User) (uid int64, err error)
+	DeleteUser(ctx context.Context, userID int64) error
+	RestoreUser(ctx context.Context, userID int64) error
+	SetAdmin(ctx context.Context, userID int64, isAdmin bool) error
+	SetDisabled(ctx context.Context, userID int64, disabled bool) error
+	PurgeDeletedUsers(ctx context.Context, deletedBefore time.Time) (int64, error)
+}
+
+type UserProvider interface {
+	UserExists(ctx context.Context, email string) (bool, error)
+	Users(ctx context.Context, includeDeleted bool) ([]models.User, error)
+}
+
+var (
+	ErrInvalidRole  = errors.New("invalid role")
+	ErrUserExists   = errors.New("user already exists")
+	ErrUserNotFound = errors.New("user not found")
+)
+
+// ImportedUser is the outcome of a single imported row.
+type ImportedUser struct {
+	UserID          int64
+	InitialPassword string
+}
+
+func New(log *slog.Logger, userSaver UserSaver, userProvider UserProvider) *Users {
+	return &Users{
+		log:          log,
+		userSaver:    userSaver,
+		userProvider: userProvider,
+	}
+}
+
+// ImportUser creates an account with a random initial password. The email is
+// expected to be normalized and validated by the caller, like for registration.
+// In dry-run mode the row is only validated and nothing is written.
+func (u *Users) ImportUser(ctx context.Context, email string, name string, class string, role string, dryRun bool) (ImportedUser, error) {
+	const op = "users.ImportUser"
+
+	log := u.log.With(slog.String("op", op), slog.Bool("dry_run", dryRun))
+
+	isAdmin, err := parseRole(role)
+	if err != nil {
+		return ImportedUser{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if dryRun {
+		exists, err := u.userProvider.UserExists(ctx, email)
+		if err != nil {
+			log.ErrorContext(ctx, "failed to check if user exists", sl.Err(err))
+
+			return ImportedUser{}, fmt.Errorf("%s: %w", op, err)
+		}
+
+		if exists {
+			return ImportedUser{}, fmt.Errorf("%s: %w", op, ErrUserExists)
+		}
+
+		return ImportedUser{}, nil
+	}
+
+	password, err := generatePassword()
+	if err != nil {
+		log.ErrorContext(ctx, "failed to generate initial password", sl.Err(err))
+
+		return ImportedUser{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	passHash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		log.ErrorContext(ctx, "failed to generate password hash", sl.Err(err))
+
+		return ImportedUser{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	id, err := u.userSaver.SaveImportedUser(ctx, models.User{
+		Email:    email,
+		PassHash: passHash,
+		Name:     name,
+		Class:    class,
+		IsAdmin:  isAdmin,
+	})
+	if err != nil {
+		if errors.Is(err, storage.ErrUserExists) {
+			return ImportedUser{}, fmt.Errorf("%s: %w", op, ErrUserExists)
+		}
+		log.ErrorContext(ctx, "failed to save user", sl.Err(err))
+
+		return ImportedUser{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.InfoContext(ctx, "user imported", slog.Int64("id", id))
+
+	return ImportedUser{
+		UserID:          id,
+		InitialPassword: password,
+	}, nil
+}
+
+// ExportUsers returns stored users without password hashes.
+func (u *Users) ExportUsers(ctx context.Context, includeDeleted bool) ([]models.User, error) {
+	const op = "users.ExportUsers"
+
+	log := u.log.With(slog.String("op", op))
+
+	log.InfoContext(ctx, "exporting users")
+
+	users, err := u.userProvider.Users(ctx, includeDeleted)
+	if err != nil {
+		log.ErrorContext(ctx, "failed to get users", sl.Err(err))
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.InfoContext(ctx, "users exported", slog.Int("count", len(users)))
+
+	return users, nil
+}
+
+// DeleteUser soft-deletes a user. It can be restored until the purge job removes it.
+func (u *Users) DeleteUser(ctx context.Context, userID int64) error {
+	const op = "users.DeleteUser"
+
+	log := u.log.With(slog.String("op", op), slog.Int64("user_id", userID))
+
+	log.InfoContext(ctx, "deleting user")
+
+	if err := u.userSaver.DeleteUser(ctx, userID); err != nil {
+		if errors.Is(err, storage.ErrUserNotFound) {
+			log.WarnContext(ctx, "user not found", sl.Err(err))
+
+			return fmt.Errorf("%s: %w", op, ErrUserNotFound)
+		}
+		log.ErrorContext(ctx, "failed to delete user", sl.Err(err))
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.InfoContext(ctx, "user deleted")
+
+	return nil
+}
+
+// RestoreUser undoes a soft deletion.
+func (u *Users) RestoreUser(ctx context.Context, userID int64) error {
+	const op = "users.RestoreUser"
+
+	log := u.log.With(slog.String("op", op), slog.Int64("user_id", userID))
+
+	log.InfoContext(ctx, "restoring user")
+
+	if err := u.userSaver.RestoreUser(ctx, userID); err != nil {
+		if errors.Is(err, storage.ErrUserNotFound) {
+			log.WarnContext(ctx, "deleted user not found", sl.Err(err))
+
+			return fmt.Errorf("%s: %w", op, ErrUserNotFound)
+		}
+		if errors.Is(err, storage.ErrUserExists) {
+			log.WarnContext(ctx, "email registered again since deletion", sl.Err(err))
+
+			return fmt.Errorf("%s: %w", op, ErrUserExists)
+		}
+		log.ErrorContext(ctx, "failed to restore user", sl.Err(err))
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.InfoContext(ctx, "user restored")
+
+	return nil
+}
+
+// SetRole changes the role of a user.
+func (u *Users) SetRole(ctx context.Context, userID int64, role string) error {
+	const op = "users.SetRole"
+
+	log := u.log.With(slog.String("op", op), slog.Int64("user_id", userID))
+
+	isAdmin, err := parseRole(role)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.InfoContext(ctx, "changing user role", slog.String("role", role))
+
+	if err := u.userSaver.SetAdmin(ctx, userID, isAdmin); err != nil {
+		if errors.Is(err, storage.ErrUserNotFound) {
+			log.WarnContext(ctx, "user not found", sl.Err(err))
+
+			return fmt.Errorf("%s: %w", op, ErrUserNotFound)
+		}
+		log.ErrorContext(ctx, "failed to change user role", sl.Err(err))
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.InfoContext(ctx, "user role changed")
+
+	return nil
+}
+
+// SetDisabled disables a user, which blocks logins, or enables it again.
+func (u *Users) SetDisabled(ctx context.Context, userID int64, disabled bool) error {
+	const op = "users.SetDisabled"
+
+	log := u.log.With(slog.String("op", op), slog.Int64("user_id", userID), slog.Bool("disabled", disabled))
+
+	log.InfoContext(ctx, "changing user state")
+
+	if err := u.userSaver.SetDisabled(ctx, userID, disabled); err != nil {
+		if errors.Is(err, storage.ErrUserNotFound) {
+			log.WarnContext(ctx, "user not found", sl.Err(err))
+
+			return fmt.Errorf("%s: %w", op, ErrUserNotFound)
+		}
+		log.ErrorContext(ctx, "failed to change user state", sl.Err(err))
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.InfoContext(ctx, "user state changed")
+
+	return nil
+}
+
+// PurgeDeleted hard-deletes users that have been soft-deleted for longer than retention.
+func (u *Users) PurgeDeleted(ctx context.Context, retention time.Duration) (int64, error) {
+	const op = "users.PurgeDeleted"
+
+	log := u.log.With(slog.String("op", op))
+
+	purged, err := u.userSaver.PurgeDeletedUsers(ctx, time.Now().Add(-retention))
+	if err != nil {
+		log.ErrorContext(ctx, "failed to purge deleted users", sl.Err(err))
+
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if purged > 0 {
+		log.InfoContext(ctx, "deleted users purged", slog.Int64("count", purged))
+	}
+
+	return purged, nil
+}
+
+func parseRole(role string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(role)) {
+	case "", models.RoleStudent:
+		return false, nil
+	case models.RoleAdmin:
+		return true, nil
+	default:
+		return false, ErrInvalidRole
+	}
+}
+
+func generatePassword() (string, error) {
+	max := big.NewInt(int64(len(initialPasswordCharset)))
+
+	buf := make([]byte, initialPasswordLen)
+	for i := range buf {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		buf[i] = initialPasswordCharset[n.Int64()]
+	}
+
+	return string(buf), nil
+}
@@ -0,0 +1,204 @@
+package postgres
+
+import (
+	"LibAssistant_sso/internal/domain/models"
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// outboxLockID serializes outbox writers, so events become visible in id order
+// and a consumer resuming after a cursor never skips a late-committed event.
+const outboxLockID int64 = 44044
+
+// outboxClaimLockID serializes claims, so a relay never claims a user's event while
+// another relay is claiming an earlier one of the same user.
+const outboxClaimLockID int64 = 44045
+
+// insertEvent records a user lifecycle event inside the transaction that changed the user.
+func insertEvent(ctx context.Context, tx pgx.Tx, eventType string, user models.User) error {
+	if _, err := tx.Exec(ctx, "SELECT pg_advisory_xact_lock($1)", outboxLockID); err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(models.UserEventPayload{
+		UserID:     user.ID,
+		Email:      user.Email,
+		Role:       user.Role(),
+		OccurredAt: time.Now().UTC(),
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, "INSERT INTO outbox(event_type, user_id, payload) VALUES($1, $2, $3)", eventType, user.ID, payload)
+
+	return err
+}
+
+// ProcessOutbox claims up to limit due events for lease and hands them to publish
+// in outbox order, recording each result as soon as it is known. Several replicas
+// can relay in parallel, each with its own claimed events; an event whose claim
+// expired, e.g. because its relay died, is claimed again.
+//
+// Events of a user are delivered in order: an event is not claimed while an earlier
+// one of the same user is waiting for a retry or claimed elsewhere, and after a
+// failed event the rest of that user's events in the batch are given back.
+// Failed events are rescheduled after backoff(attempts).
+//
+// If ctx ends, the remaining events are given back without counting an attempt.
+func (s *Storage) ProcessOutbox(
+	ctx context.Context,
+	limit int,
+	lease time.Duration,
+	publish func(ctx context.Context, event models.Event) error,
+	backoff func(attempts int) time.Duration,
+) (published int, failed int, err error) {
+	const op = "storage.postgres.ProcessOutbox"
+
+	events, err := s.claimEvents(ctx, limit, lease)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	slices.SortFunc(events, func(a, b models.Event) int { return cmp.Compare(a.ID, b.ID) })
+
+	// Results are recorded even after ctx ended, so finished deliveries are not repeated.
+	record := context.WithoutCancel(ctx)
+
+	blocked := make(map[int64]bool)
+
+	for _, event := range events {
+		if blocked[event.UserID] || ctx.Err() != nil {
+			if err := s.releaseEvent(record, event.ID); err != nil {
+				return published, failed, fmt.Errorf("%s: %w", op, err)
+			}
+
+			continue
+		}
+
+		pubErr := publish(ctx, event)
+
+		switch {
+		case pubErr == nil:
+			published++
+
+			_, err = s.db.Exec(record,
+				"UPDATE outbox SET published_at = now(), attempts = attempts + 1, last_error = NULL, locked_until = NULL WHERE id = $1",
+				event.ID,
+			)
+		case ctx.Err() != nil:
+			// The relay ran out of time; that says nothing about the event.
+			err = s.releaseEvent(record, event.ID)
+		default:
+			failed++
+			blocked[event.UserID] = true
+
+			_, err = s.db.Exec(record,
+				"UPDATE outbox SET attempts = attempts + 1, last_error = $2, next_attempt_at = now() + make_interval(secs => $3), locked_until = NULL WHERE id = $1",
+				event.ID, pubErr.Error(), backoff(event.Attempts+1).Seconds(),
+			)
+		}
+
+		if err != nil {
+			return published, failed, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return published, failed, nil
+}
+
+func (s *Storage) claimEvents(ctx context.Context, limit int, lease time.Duration) ([]models.Event, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, "SELECT pg_advisory_xact_lock($1)", outboxClaimLockID); err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.Query(ctx, `
+		UPDATE outbox SET locked_until = now() + make_interval(secs => $2)
+		WHERE id IN (
+			SELECT id FROM outbox o
+			WHERE published_at IS NULL
+				AND next_attempt_at <= now()
+				AND (locked_until IS NULL OR locked_until < now())
+				AND NOT EXISTS (
+					SELECT 1 FROM outbox prev
+					WHERE prev.user_id = o.user_id
+						AND prev.id < o.id
+						AND prev.published_at IS NULL
+						AND (prev.next_attempt_at > now() OR prev.locked_until >= now())
+				)
+			ORDER BY id
+			LIMIT $1
+		)
+		RETURNING id, event_id::text, event_type, user_id, payload, created_at, attempts`,
+		limit, lease.Seconds())
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := pgx.CollectRows(rows, scanEvent)
+	if err != nil {
+		return nil, err
+	}
+
+	return events, tx.Commit(ctx)
+}
+
+// releaseEvent gives a claimed event back, so it can be claimed again right away.
+func (s *Storage) releaseEvent(ctx context.Context, id int64) error {
+	_, err := s.db.Exec(ctx, "UPDATE outbox SET locked_until = NULL WHERE id = $1", id)
+
+	return err
+}
+
+// EventsAfter returns up to limit events with an id greater than cursor, in id order.
+func (s *Storage) EventsAfter(ctx context.Context, cursor int64, limit int) ([]models.Event, error) {
+	const op = "storage.postgres.EventsAfter"
+
+	rows, err := s.db.Query(ctx, `
+		SELECT id, event_id::text, event_type, user_id, payload, created_at, attempts
+		FROM outbox
+		WHERE id > $1
+		ORDER BY id
+		LIMIT $2`, cursor, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	events, err := pgx.CollectRows(rows, scanEvent)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return events, nil
+}
+
+// LastEventID returns the id of the newest event, or 0 if the outbox is empty.
+func (s *Storage) LastEventID(ctx context.Context) (int64, error) {
+	const op = "storage.postgres.LastEventID"
+
+	var id int64
+
+	if err := s.db.QueryRow(ctx, "SELECT COALESCE(MAX(id), 0) FROM outbox").Scan(&id); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+func scanEvent(row pgx.CollectableRow) (models.Event, error) {
+	var e models.Event
+	err := row.Scan(&e.ID, &e.EventID, &e.Type, &e.UserID, &e.Payload, &e.CreatedAt, &e.Attempts)
+	return e, err
+}
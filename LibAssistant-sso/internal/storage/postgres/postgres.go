@@ -10,17 +10,25 @@ import (
 	"time"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type Storage struct {
-	db *pgx.Conn
+	db *pgxpool.Pool
 }
 
-// Opens connection to postgresql DB
+// Opens connection pool to postgresql DB
 func New(ctx context.Context, dsn string) (*Storage, error) {
 	const op = "storage.postgres.New"
 
-	db, err := pgx.Connect(ctx, dsn)
+	cfg, err := pgxpool.ParseConfig(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	cfg.ConnConfig.Tracer = queryTracer{}
+
+	db, err := pgxpool.NewWithConfig(ctx, cfg)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
@@ -28,16 +36,31 @@ func New(ctx context.Context, dsn string) (*Storage, error) {
 	return &Storage{db: db}, nil
 }
 
+// Close closes all connections of the pool.
+func (s *Storage) Close() {
+	s.db.Close()
+}
+
+// Stat returns connection pool statistics for metrics.
+func (s *Storage) Stat() *pgxpool.Stat {
+	return s.db.Stat()
+}
+
+func (s *Storage) Ping(ctx context.Context) error {
+	const op = "storage.postgres.Ping"
+
+	if err := s.db.Ping(ctx); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
 func (s *Storage) SaveUser(ctx context.Context, email string, passHash []byte) (int64, error) {
 	const op = "storage.postgres.SaveUser"
 
-	id := time.Now().Unix()
-
-	_, err := s.db.Exec(ctx, "INSERT INTO users(id, email, pass_hash) VALUES($1, $2, $3)", id, email, passHash)
+	id, err := s.insertUser(ctx, models.User{Email: email, PassHash: passHash})
 	if err != nil {
-		if strings.Contains(err.Error(), "duplicate key value violates unique constraint") {
-			return 0, fmt.Errorf("%s: %w", op, storage.ErrUserExists)
-		}
 		return 0, fmt.Errorf("%s: %w", op, err)
 	}
 
@@ -48,7 +71,7 @@ func (s *Storage) SaveUser(ctx context.Context, email string, passHash []byte) (
 func (s *Storage) User(ctx context.Context, email string) (models.User, error) {
 	const op = "storage.postgres.User"
 
-	rows, err := s.db.Query(ctx, "SELECT id, email, pass_hash FROM users WHERE email = $1", email)
+	rows, err := s.db.Query(ctx, "SELECT id, email, pass_hash, is_admin, disabled_at FROM users WHERE email = $1 AND deleted_at IS NULL", email)
 	if err != nil {
 		return models.User{}, fmt.Errorf("%s: %w", op, err)
 	}
@@ -56,7 +79,7 @@ func (s *Storage) User(ctx context.Context, email string) (models.User, error) {
 	var user models.User
 
 	for rows.Next() {
-		if err := rows.Scan(&user.ID, &user.Email, &user.PassHash); err != nil {
+		if err := rows.Scan(&user.ID, &user.Email, &user.PassHash, &user.IsAdmin, &user.DisabledAt); err != nil {
 			if errors.Is(err, pgx.ErrNoRows) {
 				return models.User{}, fmt.Errorf("%s: %w", op, storage.ErrUserNotFound)
 			}
@@ -71,13 +94,8 @@ func (s *Storage) User(ctx context.Context, email string) (models.User, error) {
 func (s *Storage) SaveAdmin(ctx context.Context, email string, passHash []byte) (uid int64, err error) {
 	const op = "storage.postgres.SaveAdmin"
 
-	id := time.Now().Unix()
-
-	_, err = s.db.Exec(ctx, "INSERT INTO users(id, email, pass_hash, is_admin) VALUES($1, $2, $3, $4)", id, email, passHash, true)
+	id, err := s.insertUser(ctx, models.User{Email: email, PassHash: passHash, IsAdmin: true})
 	if err != nil {
-		if strings.Contains(err.Error(), "duplicate key value violates unique constraint") {
-			return 0, fmt.Errorf("%s: %w", op, storage.ErrUserExists)
-		}
 		return 0, fmt.Errorf("%s: %w", op, err)
 	}
 
@@ -88,7 +106,7 @@ func (s *Storage) IsAdmin(ctx context.Context, userID int64) (bool, error) {
     const op = "storage.postgres.IsAdmin"
 
     var isAdmin bool
-    err := s.db.QueryRow(ctx, "SELECT is_admin FROM users WHERE id = $1", userID).Scan(&isAdmin)
+    err := s.db.QueryRow(ctx, "SELECT is_admin FROM users WHERE id = $1 AND deleted_at IS NULL", userID).Scan(&isAdmin)
     if err != nil {
         if errors.Is(err, pgx.ErrNoRows) {
             return false, fmt.Errorf("%s: %w", op, storage.ErrUserNotFound)
@@ -97,3 +115,290 @@ func (s *Storage) IsAdmin(ctx context.Context, userID int64) (bool, error) {
     }
     return isAdmin, nil
 }
+
+// SaveImportedUser stores a user created by a bulk import together with its profile fields.
+func (s *Storage) SaveImportedUser(ctx context.Context, user models.User) (int64, error) {
+	const op = "storage.postgres.SaveImportedUser"
+
+	id, err := s.insertUser(ctx, user)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+// insertUser stores a user and its user.registered event in one transaction.
+func (s *Storage) insertUser(ctx context.Context, user models.User) (int64, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(ctx,
+		"INSERT INTO users(email, pass_hash, name, class, is_admin) VALUES($1, $2, $3, $4, $5) RETURNING id",
+		user.Email, user.PassHash, user.Name, user.Class, user.IsAdmin,
+	).Scan(&user.ID)
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate key value violates unique constraint") {
+			return 0, storage.ErrUserExists
+		}
+		return 0, err
+	}
+
+	if err := insertEvent(ctx, tx, models.EventUserRegistered, user); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+
+	return user.ID, nil
+}
+
+// UserExists reports whether a user with the given email is stored and not deleted.
+// The email of a soft-deleted user can be registered again.
+func (s *Storage) UserExists(ctx context.Context, email string) (bool, error) {
+	const op = "storage.postgres.UserExists"
+
+	var exists bool
+
+	err := s.db.QueryRow(ctx, "SELECT EXISTS(SELECT 1 FROM users WHERE email = $1 AND deleted_at IS NULL)", email).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return exists, nil
+}
+
+// Users returns users ordered by id. Soft-deleted users are skipped unless includeDeleted is set.
+// Password hashes are not selected.
+func (s *Storage) Users(ctx context.Context, includeDeleted bool) ([]models.User, error) {
+	const op = "storage.postgres.Users"
+
+	rows, err := s.db.Query(ctx, `
+		SELECT id, email, name, class, is_admin, created_at, updated_at, last_login_at, disabled_at, deleted_at
+		FROM users
+		WHERE $1 OR deleted_at IS NULL
+		ORDER BY id`, includeDeleted)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var users []models.User
+
+	for rows.Next() {
+		var user models.User
+
+		if err := rows.Scan(
+			&user.ID, &user.Email, &user.Name, &user.Class, &user.IsAdmin,
+			&user.CreatedAt, &user.UpdatedAt, &user.LastLoginAt, &user.DisabledAt, &user.DeletedAt,
+		); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		users = append(users, user)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return users, nil
+}
+
+// UpdateLastLogin stamps the time of a successful login.
+func (s *Storage) UpdateLastLogin(ctx context.Context, userID int64) error {
+	const op = "storage.postgres.UpdateLastLogin"
+
+	_, err := s.db.Exec(ctx, "UPDATE users SET last_login_at = now() WHERE id = $1", userID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// DeleteUser soft-deletes a user. The row is kept until it is purged.
+func (s *Storage) DeleteUser(ctx context.Context, userID int64) error {
+	const op = "storage.postgres.DeleteUser"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	user := models.User{ID: userID}
+
+	err = tx.QueryRow(ctx,
+		"UPDATE users SET deleted_at = now(), updated_at = now() WHERE id = $1 AND deleted_at IS NULL RETURNING email, is_admin",
+		userID,
+	).Scan(&user.Email, &user.IsAdmin)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("%s: %w", op, storage.ErrUserNotFound)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := insertEvent(ctx, tx, models.EventUserDeleted, user); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// SetAdmin changes the role of a user. A user.role_changed event is recorded
+// only when the role actually changes.
+func (s *Storage) SetAdmin(ctx context.Context, userID int64, isAdmin bool) error {
+	const op = "storage.postgres.SetAdmin"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	user := models.User{ID: userID}
+
+	err = tx.QueryRow(ctx,
+		"SELECT email, is_admin FROM users WHERE id = $1 AND deleted_at IS NULL FOR UPDATE",
+		userID,
+	).Scan(&user.Email, &user.IsAdmin)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("%s: %w", op, storage.ErrUserNotFound)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if user.IsAdmin == isAdmin {
+		return nil
+	}
+
+	if _, err := tx.Exec(ctx, "UPDATE users SET is_admin = $2, updated_at = now() WHERE id = $1", userID, isAdmin); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	user.IsAdmin = isAdmin
+
+	if err := insertEvent(ctx, tx, models.EventUserRoleChanged, user); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// SetDisabled disables or re-enables a user. A user.disabled or user.enabled
+// event is recorded only when the state actually changes.
+func (s *Storage) SetDisabled(ctx context.Context, userID int64, disabled bool) error {
+	const op = "storage.postgres.SetDisabled"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	var (
+		user       = models.User{ID: userID}
+		isDisabled bool
+	)
+
+	err = tx.QueryRow(ctx,
+		"SELECT email, is_admin, disabled_at IS NOT NULL FROM users WHERE id = $1 AND deleted_at IS NULL FOR UPDATE",
+		userID,
+	).Scan(&user.Email, &user.IsAdmin, &isDisabled)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("%s: %w", op, storage.ErrUserNotFound)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if isDisabled == disabled {
+		return nil
+	}
+
+	query, eventType := "UPDATE users SET disabled_at = NULL, updated_at = now() WHERE id = $1", models.EventUserEnabled
+	if disabled {
+		query, eventType = "UPDATE users SET disabled_at = now(), updated_at = now() WHERE id = $1", models.EventUserDisabled
+	}
+
+	if _, err := tx.Exec(ctx, query, userID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := insertEvent(ctx, tx, eventType, user); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// RestoreUser undoes a soft deletion that has not been purged yet and records a
+// user.restored event. It fails with storage.ErrUserExists if the email has been
+// registered again since.
+func (s *Storage) RestoreUser(ctx context.Context, userID int64) error {
+	const op = "storage.postgres.RestoreUser"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	user := models.User{ID: userID}
+
+	err = tx.QueryRow(ctx,
+		"UPDATE users SET deleted_at = NULL, updated_at = now() WHERE id = $1 AND deleted_at IS NOT NULL RETURNING email, is_admin",
+		userID,
+	).Scan(&user.Email, &user.IsAdmin)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("%s: %w", op, storage.ErrUserNotFound)
+		}
+		if strings.Contains(err.Error(), "duplicate key value violates unique constraint") {
+			return fmt.Errorf("%s: %w", op, storage.ErrUserExists)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := insertEvent(ctx, tx, models.EventUserRestored, user); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// PurgeDeletedUsers hard-deletes users that were soft-deleted before the given time.
+func (s *Storage) PurgeDeletedUsers(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	const op = "storage.postgres.PurgeDeletedUsers"
+
+	tag, err := s.db.Exec(ctx, "DELETE FROM users WHERE deleted_at < $1", deletedBefore)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tag.RowsAffected(), nil
+}
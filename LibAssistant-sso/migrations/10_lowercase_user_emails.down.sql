@@ -0,0 +1 @@
+-- The original letter case of emails is not kept, so there is nothing to restore.
\ No newline at end of file
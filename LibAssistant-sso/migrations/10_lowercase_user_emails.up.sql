@@ -0,0 +1,6 @@
+UPDATE users u SET email = lower(u.email)
+WHERE u.email <> lower(u.email)
+  AND (u.deleted_at IS NOT NULL OR NOT EXISTS (
+      SELECT 1 FROM users o
+      WHERE o.id <> u.id AND lower(o.email) = lower(u.email) AND o.deleted_at IS NULL
+  ));
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE users
+    ADD COLUMN name  TEXT NOT NULL DEFAULT '',
+    ADD COLUMN class TEXT NOT NULL DEFAULT '';
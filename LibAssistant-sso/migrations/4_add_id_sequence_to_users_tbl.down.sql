@@ -0,0 +1,3 @@
+ALTER TABLE users
+    ALTER COLUMN id DROP DEFAULT;
+DROP SEQUENCE IF EXISTS users_id_seq;
\ No newline at end of file
@@ -0,0 +1,4 @@
+CREATE SEQUENCE IF NOT EXISTS users_id_seq OWNED BY users.id;
+SELECT setval('users_id_seq', (SELECT COALESCE(MAX(id), 0) + 1 FROM users), false);
+ALTER TABLE users
+    ALTER COLUMN id SET DEFAULT nextval('users_id_seq');
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_users_deleted_at;
+ALTER TABLE users
+    DROP COLUMN created_at,
+    DROP COLUMN updated_at,
+    DROP COLUMN last_login_at,
+    DROP COLUMN deleted_at;
\ No newline at end of file
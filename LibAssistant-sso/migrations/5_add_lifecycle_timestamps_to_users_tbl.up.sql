@@ -0,0 +1,6 @@
+ALTER TABLE users
+    ADD COLUMN created_at    TIMESTAMPTZ NOT NULL DEFAULT now(),
+    ADD COLUMN updated_at    TIMESTAMPTZ NOT NULL DEFAULT now(),
+    ADD COLUMN last_login_at TIMESTAMPTZ,
+    ADD COLUMN deleted_at    TIMESTAMPTZ;
+CREATE INDEX IF NOT EXISTS idx_users_deleted_at ON users (deleted_at) WHERE deleted_at IS NOT NULL;
@@ -0,0 +1,5 @@
+// Package protos holds the SSO-owned gRPC APIs that are not part of the
+// shared github.com/MKode312/protos module.
+package protos
+
+//go:generate protoc -I . --go_out=gen/go --go_opt=paths=source_relative --go-grpc_out=gen/go --go-grpc_opt=paths=source_relative LibAssistant/users/users.proto
@@ -19,7 +19,7 @@ func TestRegisterAsAdmin_DuplicateRegistrationAsAdmin(t *testing.T) {
 	respReg, err := st.AuthClient.RegisterAsAdmin(ctx, &ssov1.RegisterAsAdminRequest{
 		Email:    email,
 		Password: pass,
-		AdminSecret: adminSecret,
+		AdminSecret: st.Cfg.AdminSecret,
 	})
 
 	require.NoError(t, err)
@@ -28,7 +28,7 @@ func TestRegisterAsAdmin_DuplicateRegistrationAsAdmin(t *testing.T) {
 	respReg, err = st.AuthClient.RegisterAsAdmin(ctx, &ssov1.RegisterAsAdminRequest{
 		Email:    email,
 		Password: pass,
-		AdminSecret: adminSecret,
+		AdminSecret: st.Cfg.AdminSecret,
 	})
 	require.Error(t, err)
 	assert.Empty(t, respReg.GetUserId())
@@ -62,7 +62,7 @@ func TestIsAdmin_HappyPath(t *testing.T) {
 	respRegAsAdmin, err := st.AuthClient.RegisterAsAdmin(ctx, &ssov1.RegisterAsAdminRequest{
 		Email: email,
 		Password: password,
-		AdminSecret: adminSecret,
+		AdminSecret: st.Cfg.AdminSecret,
 	})
 
 	require.NoError(t, err)
@@ -107,7 +107,7 @@ func TestIsAdmin_True(t *testing.T) {
 	respRegAsAdmin, err := st.AuthClient.RegisterAsAdmin(ctx, &ssov1.RegisterAsAdminRequest{
 		Email: email,
 		Password: password,
-		AdminSecret: adminSecret,
+		AdminSecret: st.Cfg.AdminSecret,
 	})
 
 	require.NoError(t, err)
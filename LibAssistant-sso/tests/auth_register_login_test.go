@@ -2,6 +2,8 @@ package tests
 
 import (
 	"LibAssistant_sso/tests/suite"
+	"math"
+	"strings"
 	"testing"
 	"time"
 
@@ -13,9 +15,6 @@ import (
 )
 
 const (
-	appSecret = "test-secret"
-	adminSecret = "test-secret"
-
 	passDefaultLen = 10
 )
 
@@ -45,7 +44,7 @@ func TestRegisterLogin_Login_HappyPath(t *testing.T) {
 	require.NotEmpty(t, token)
 
 	tokenParsed, err := jwt.Parse(token, func(t *jwt.Token) (interface{}, error) {
-		return []byte(appSecret), nil
+		return []byte(st.Cfg.AppSecret), nil
 	})
 	require.NoError(t, err)
 
@@ -54,10 +53,12 @@ func TestRegisterLogin_Login_HappyPath(t *testing.T) {
 
 	assert.Equal(t, respReg.GetUserId(), int64(claims["uid"].(float64)))
 	assert.Equal(t, email, claims["email"].(string))
+	assert.Equal(t, []any{"student"}, claims["roles"])
 
 	const deltaSeconds = 1
 
 	assert.InDelta(t, loginTime.Add(st.Cfg.TokenTTL).Unix(), claims["exp"].(float64), deltaSeconds)
+	assert.InDelta(t, loginTime.Unix(), claims["iat"].(float64), deltaSeconds)
 }
 
 func TestRegisterLogin_DuplicateRegistration(t *testing.T) {
@@ -83,6 +84,41 @@ func TestRegisterLogin_DuplicateRegistration(t *testing.T) {
 	assert.ErrorContains(t, err, "user already exists")
 }
 
+func TestRegisterLogin_EmailCase(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	email := gofakeit.Email()
+	pass := randomFakePassword()
+
+	respReg, err := st.AuthClient.Register(ctx, &ssov1.RegisterRequest{
+		Email:    " " + strings.ToUpper(email) + " ",
+		Password: pass,
+	})
+	require.NoError(t, err)
+
+	respLogin, err := st.AuthClient.Login(ctx, &ssov1.LoginRequest{
+		Email:    email,
+		Password: pass,
+	})
+	require.NoError(t, err)
+
+	tokenParsed, err := jwt.Parse(respLogin.GetToken(), func(t *jwt.Token) (interface{}, error) {
+		return []byte(st.Cfg.AppSecret), nil
+	})
+	require.NoError(t, err)
+
+	claims, ok := tokenParsed.Claims.(jwt.MapClaims)
+	require.True(t, ok)
+	assert.Equal(t, respReg.GetUserId(), int64(claims["uid"].(float64)))
+	assert.Equal(t, strings.ToLower(email), claims["email"].(string))
+
+	_, err = st.AuthClient.Register(ctx, &ssov1.RegisterRequest{
+		Email:    strings.ToLower(email),
+		Password: pass,
+	})
+	assert.ErrorContains(t, err, "user already exists")
+}
+
 func TestRegister_FailCases(t *testing.T) {
 	ctx, st := suite.New(t)
 
@@ -182,6 +218,7 @@ func randomFakePassword() string {
 	return gofakeit.Password(true, true, true, true, false, passDefaultLen)
 }
 
+// randomFakeID returns a positive id, since SSO rejects non-positive ones as invalid.
 func randomFakeID() int64 {
-	return gofakeit.Int64()
+	return int64(gofakeit.Number(1, math.MaxInt32))
 }
\ No newline at end of file
@@ -0,0 +1,75 @@
+package tests
+
+import (
+	"LibAssistant_sso/tests/suite"
+	"testing"
+
+	ssov1 "github.com/MKode312/protos/gen/go/LibAssistant/sso"
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRegister_FieldViolations(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	_, err := st.AuthClient.Register(ctx, &ssov1.RegisterRequest{
+		Email:    "not-an-email",
+		Password: "short",
+	})
+	require.Error(t, err)
+
+	violations := fieldViolations(t, err)
+	assert.Equal(t, "email must be a valid email address", violations["email"])
+	assert.Equal(t, "password must be at least 8 characters long", violations["password"])
+}
+
+func TestRegisterAsAdmin_FieldViolations(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	_, err := st.AuthClient.RegisterAsAdmin(ctx, &ssov1.RegisterAsAdminRequest{
+		Email:    gofakeit.Email(),
+		Password: randomFakePassword(),
+	})
+	require.Error(t, err)
+
+	violations := fieldViolations(t, err)
+	assert.Len(t, violations, 1)
+	assert.Equal(t, "admin secret is required", violations["admin_secret"])
+}
+
+func TestIsAdmin_NegativeID(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	_, err := st.AuthClient.IsAdmin(ctx, &ssov1.IsAdminRequest{UserId: -1})
+	require.Error(t, err)
+
+	violations := fieldViolations(t, err)
+	assert.Equal(t, "user id must be greater than 0", violations["user_id"])
+}
+
+func fieldViolations(t *testing.T, err error) map[string]string {
+	t.Helper()
+
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	require.Equal(t, codes.InvalidArgument, st.Code())
+
+	violations := make(map[string]string)
+	for _, detail := range st.Details() {
+		br, ok := detail.(*errdetails.BadRequest)
+		if !ok {
+			continue
+		}
+
+		for _, v := range br.GetFieldViolations() {
+			violations[v.GetField()] = v.GetDescription()
+		}
+	}
+	require.NotEmpty(t, violations)
+
+	return violations
+}
@@ -0,0 +1,152 @@
+package tests
+
+import (
+	"LibAssistant_sso/tests/suite"
+	"bytes"
+	"encoding/json"
+	"net"
+	"net/http"
+	"strconv"
+	"testing"
+
+	ssov1 "github.com/MKode312/protos/gen/go/LibAssistant/sso"
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGateway_RegisterOverHTTPLoginOverGRPC(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	email := gofakeit.Email()
+	pass := randomFakePassword()
+
+	var reg struct {
+		UserID string `json:"user_id"`
+	}
+	code := gatewayCall(t, st, http.MethodPost, "/v1/auth/register", map[string]string{
+		"email":    email,
+		"password": pass,
+	}, &reg)
+	require.Equal(t, http.StatusOK, code)
+	require.NotEmpty(t, reg.UserID)
+
+	respLogin, err := st.AuthClient.Login(ctx, &ssov1.LoginRequest{
+		Email:    email,
+		Password: pass,
+	})
+	require.NoError(t, err)
+	assert.NotEmpty(t, respLogin.GetToken())
+}
+
+func TestGateway_RegisterOverGRPCLoginOverHTTP(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	email := gofakeit.Email()
+	pass := randomFakePassword()
+
+	respReg, err := st.AuthClient.Register(ctx, &ssov1.RegisterRequest{
+		Email:    email,
+		Password: pass,
+	})
+	require.NoError(t, err)
+
+	var login struct {
+		Token string `json:"token"`
+	}
+	code := gatewayCall(t, st, http.MethodPost, "/v1/auth/login", map[string]string{
+		"email":    email,
+		"password": pass,
+	}, &login)
+	require.Equal(t, http.StatusOK, code)
+	assert.NotEmpty(t, login.Token)
+
+	var isAdmin struct {
+		IsAdmin bool `json:"is_admin"`
+	}
+	code = gatewayCall(t, st, http.MethodGet, "/v1/auth/users/"+strconv.FormatInt(respReg.GetUserId(), 10)+"/is-admin", nil, &isAdmin)
+	require.Equal(t, http.StatusOK, code)
+	assert.False(t, isAdmin.IsAdmin)
+}
+
+func TestGateway_ValidationMatchesGRPC(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	_, grpcErr := st.AuthClient.Register(ctx, &ssov1.RegisterRequest{
+		Email:    "not-an-email",
+		Password: "short",
+	})
+	require.Error(t, grpcErr)
+
+	var status struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Details []struct {
+			Type            string `json:"@type"`
+			FieldViolations []struct {
+				Field string `json:"field"`
+			} `json:"field_violations"`
+		} `json:"details"`
+	}
+	code := gatewayCall(t, st, http.MethodPost, "/v1/auth/register", map[string]string{
+		"email":    "not-an-email",
+		"password": "short",
+	}, &status)
+	require.Equal(t, http.StatusBadRequest, code)
+
+	assert.Contains(t, grpcErr.Error(), status.Message)
+	require.Len(t, status.Details, 1)
+	assert.Equal(t, "type.googleapis.com/google.rpc.BadRequest", status.Details[0].Type)
+	assert.Len(t, status.Details[0].FieldViolations, 2)
+}
+
+func TestGateway_WrongAdminSecret(t *testing.T) {
+	_, st := suite.New(t)
+
+	code := gatewayCall(t, st, http.MethodPost, "/v1/auth/register-admin", map[string]string{
+		"email":        gofakeit.Email(),
+		"password":     randomFakePassword(),
+		"admin_secret": randomFakeAdminKey(),
+	}, nil)
+	assert.Equal(t, http.StatusForbidden, code)
+}
+
+func TestGateway_OpenAPI(t *testing.T) {
+	_, st := suite.New(t)
+
+	var doc struct {
+		OpenAPI string                    `json:"openapi"`
+		Paths   map[string]map[string]any `json:"paths"`
+	}
+	code := gatewayCall(t, st, http.MethodGet, "/openapi.json", nil, &doc)
+	require.Equal(t, http.StatusOK, code)
+
+	assert.Equal(t, "3.0.3", doc.OpenAPI)
+	assert.Contains(t, doc.Paths, "/v1/auth/login")
+	assert.Contains(t, doc.Paths["/v1/auth/users/{user_id}/is-admin"], "get")
+}
+
+func gatewayCall(t *testing.T, st *suite.Suite, method, path string, body any, out any) int {
+	t.Helper()
+
+	var reqBody bytes.Buffer
+	if body != nil {
+		require.NoError(t, json.NewEncoder(&reqBody).Encode(body))
+	}
+
+	url := "http://" + net.JoinHostPort("localhost", strconv.Itoa(st.Cfg.Gateway.Port)) + path
+
+	req, err := http.NewRequestWithContext(st.Context(), method, url, &reqBody)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	if out != nil {
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(out))
+	}
+
+	return resp.StatusCode
+}
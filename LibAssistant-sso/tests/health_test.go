@@ -0,0 +1,28 @@
+package tests
+
+import (
+	"LibAssistant_sso/tests/suite"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestHealthCheck_Serving(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	for _, service := range []string{"", "auth.Auth", "users.Users"} {
+		resp, err := st.HealthClient.Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+		require.NoError(t, err)
+		assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.GetStatus(), service)
+	}
+}
+
+func TestHealthCheck_UnknownService(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	_, err := st.HealthClient.Check(ctx, &healthpb.HealthCheckRequest{Service: "unknown.Service"})
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "unknown service")
+}
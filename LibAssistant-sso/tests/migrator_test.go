@@ -0,0 +1,91 @@
+package tests
+
+import (
+	"LibAssistant_sso/internal/migrator"
+	"LibAssistant_sso/tests/suite"
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const migrationsPath = "../migrations"
+
+// TestMigrator_Concurrent starts two migrators on an empty schema while the
+// migration lock is held: both wait, then one applies every migration and the
+// other finds nothing left to do.
+func TestMigrator_Concurrent(t *testing.T) {
+	_, st := suite.New(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	admin, err := pgx.Connect(ctx, st.Cfg.Postgres.DBurl)
+	require.NoError(t, err)
+	defer admin.Close(context.Background())
+
+	schema := fmt.Sprintf("migrator_test_%d", time.Now().UnixNano())
+
+	_, err = admin.Exec(ctx, "CREATE SCHEMA "+schema)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_, _ = admin.Exec(context.Background(), "DROP SCHEMA "+schema+" CASCADE")
+	})
+
+	dsn := st.Cfg.Postgres.DBurl + "&search_path=" + schema
+
+	// Hold the lock the migrators take, so both are known to be waiting.
+	_, err = admin.Exec(ctx, "SELECT pg_advisory_lock($1)", migrator.AdvisoryLockID)
+	require.NoError(t, err)
+
+	var logs [2]bytes.Buffer
+	done := make(chan error, 2)
+
+	for i := range logs {
+		log := slog.New(slog.NewTextHandler(&logs[i], nil))
+
+		go func() {
+			done <- migrator.Up(ctx, log, dsn, migrationsPath)
+		}()
+	}
+
+	select {
+	case err := <-done:
+		t.Fatalf("a migrator ran while the lock was held: %v", err)
+	case <-time.After(500 * time.Millisecond):
+	}
+
+	require.ErrorIs(t, migrator.CheckVersion(ctx, dsn), migrator.ErrSchemaOutdated)
+
+	_, err = admin.Exec(ctx, "SELECT pg_advisory_unlock($1)", migrator.AdvisoryLockID)
+	require.NoError(t, err)
+
+	for range logs {
+		require.NoError(t, <-done)
+	}
+
+	require.NoError(t, migrator.CheckVersion(ctx, dsn))
+
+	var version uint
+	require.NoError(t, admin.QueryRow(ctx, "SELECT version FROM "+schema+".schema_migrations").Scan(&version))
+	assert.Equal(t, migrator.SchemaVersion, version)
+
+	applied, unchanged := 0, 0
+	for i := range logs {
+		if bytes.Contains(logs[i].Bytes(), []byte("migrations applied successfully")) {
+			applied++
+		}
+		if bytes.Contains(logs[i].Bytes(), []byte("no migrations to apply")) {
+			unchanged++
+		}
+	}
+
+	assert.Equal(t, 1, applied, "one migrator applies the migrations")
+	assert.Equal(t, 1, unchanged, "the other waits for it and finds nothing to do")
+}
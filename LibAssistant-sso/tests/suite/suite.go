@@ -4,29 +4,64 @@ import (
 	"LibAssistant_sso/internal/config"
 	"context"
 	"net"
+	"os"
 	"strconv"
+	"sync"
 	"testing"
 
+	usersv1 "LibAssistant_sso/protos/gen/go/LibAssistant/users"
+
 	ssov1 "github.com/MKode312/protos/gen/go/LibAssistant/sso"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const (
+	grpcHost   = "localhost"
+	configPath = "../config/local.yaml"
 )
 
+// AppSecret and AdminSecret are used when APP_SECRET and ADMIN_SECRET are not set,
+// so the tests run against a local server started with the same values.
 const (
-	grpcHost = "localhost"
+	AppSecret   = "test-secret"
+	AdminSecret = "test-secret"
+)
+
+var (
+	loadOnce sync.Once
+	cfg      *config.Config
 )
 
+// loadConfig loads configPath once for all tests, which run in parallel.
+func loadConfig() *config.Config {
+	loadOnce.Do(func() {
+		for env, value := range map[string]string{"APP_SECRET": AppSecret, "ADMIN_SECRET": AdminSecret} {
+			if os.Getenv(env) == "" && os.Getenv(env+"_FILE") == "" {
+				_ = os.Setenv(env, value)
+			}
+		}
+
+		cfg = config.MustLoadByPath(configPath)
+	})
+
+	return cfg
+}
+
 type Suite struct {
 	*testing.T
-	Cfg *config.Config
-	AuthClient ssov1.AuthClient
+	Cfg          *config.Config
+	AuthClient   ssov1.AuthClient
+	UsersClient  usersv1.UsersClient
+	HealthClient healthpb.HealthClient
 }
 
 func New(t *testing.T) (context.Context, *Suite) {
 	t.Helper()
 	t.Parallel()
 
-	cfg := config.MustLoadByPath("../config/local.yaml")
+	cfg := loadConfig()
 
 	ctx, cancelCtx := context.WithTimeout(context.Background(), cfg.GRPC.Timeout)
 
@@ -40,10 +75,12 @@ func New(t *testing.T) (context.Context, *Suite) {
 		t.Fatalf("grpc server connection failed: %v", err)
 	}
 
-	return  ctx, &Suite{
-		T: t,
-		Cfg: cfg,
-		AuthClient: ssov1.NewAuthClient(cc),
+	return ctx, &Suite{
+		T:            t,
+		Cfg:          cfg,
+		AuthClient:   ssov1.NewAuthClient(cc),
+		UsersClient:  usersv1.NewUsersClient(cc),
+		HealthClient: healthpb.NewHealthClient(cc),
 	}
 }
 
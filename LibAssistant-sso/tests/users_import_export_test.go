@@ -0,0 +1,136 @@
+package tests
+
+import (
+	"LibAssistant_sso/tests/suite"
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	usersv1 "LibAssistant_sso/protos/gen/go/LibAssistant/users"
+
+	ssov1 "github.com/MKode312/protos/gen/go/LibAssistant/sso"
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestImportUsers_DryRunThenImport(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	ctx = adminContext(ctx, t, st)
+
+	student := &usersv1.User{Email: gofakeit.Email(), Name: gofakeit.Name(), Class: "7B", Role: "student"}
+	invalid := &usersv1.User{Email: "not-an-email", Name: gofakeit.Name(), Class: "7B", Role: "student"}
+
+	dryRun := importUsers(ctx, t, st, true, student, invalid)
+
+	require.True(t, dryRun.GetDryRun())
+	require.Len(t, dryRun.GetResults(), 2)
+	assert.Equal(t, usersv1.ImportStatus_IMPORT_STATUS_WOULD_CREATE, dryRun.GetResults()[0].GetStatus())
+	assert.Empty(t, dryRun.GetResults()[0].GetUserId())
+	assert.Equal(t, usersv1.ImportStatus_IMPORT_STATUS_FAILED, dryRun.GetResults()[1].GetStatus())
+	assert.Equal(t, "invalid email", dryRun.GetResults()[1].GetError())
+
+	imported := importUsers(ctx, t, st, false, student, student)
+
+	require.Len(t, imported.GetResults(), 2)
+	assert.Equal(t, usersv1.ImportStatus_IMPORT_STATUS_CREATED, imported.GetResults()[0].GetStatus())
+	assert.NotEmpty(t, imported.GetResults()[0].GetUserId())
+	assert.Equal(t, usersv1.ImportStatus_IMPORT_STATUS_FAILED, imported.GetResults()[1].GetStatus())
+	assert.Equal(t, "duplicate email in import", imported.GetResults()[1].GetError())
+
+	_, err := st.AuthClient.Login(ctx, &ssov1.LoginRequest{
+		Email:    student.GetEmail(),
+		Password: imported.GetResults()[0].GetInitialPassword(),
+	})
+	require.NoError(t, err)
+
+	again := importUsers(ctx, t, st, false, student)
+
+	require.Len(t, again.GetResults(), 1)
+	assert.Equal(t, "user already exists", again.GetResults()[0].GetError())
+}
+
+func TestExportUsers_ContainsImportedUser(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	ctx = adminContext(ctx, t, st)
+
+	student := &usersv1.User{Email: gofakeit.Email(), Name: gofakeit.Name(), Class: "9A", Role: "student"}
+
+	imported := importUsers(ctx, t, st, false, student)
+	require.Equal(t, usersv1.ImportStatus_IMPORT_STATUS_CREATED, imported.GetResults()[0].GetStatus())
+
+	stream, err := st.UsersClient.ExportUsers(ctx, &usersv1.ExportUsersRequest{})
+	require.NoError(t, err)
+
+	var found *usersv1.User
+	for {
+		resp, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+
+		if resp.GetUser().GetEmail() == student.GetEmail() {
+			found = resp.GetUser()
+		}
+	}
+
+	require.NotNil(t, found)
+	assert.Equal(t, imported.GetResults()[0].GetUserId(), found.GetId())
+	assert.Equal(t, student.GetName(), found.GetName())
+	assert.Equal(t, student.GetClass(), found.GetClass())
+	assert.Equal(t, "student", found.GetRole())
+}
+
+func TestImportUsers_RequiresAdmin(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	stream, err := st.UsersClient.ImportUsers(ctx)
+	require.NoError(t, err)
+
+	_, err = stream.CloseAndRecv()
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "authorization token is required")
+}
+
+func adminContext(ctx context.Context, t *testing.T, st *suite.Suite) context.Context {
+	t.Helper()
+
+	email := gofakeit.Email()
+	password := randomFakePassword()
+
+	_, err := st.AuthClient.RegisterAsAdmin(ctx, &ssov1.RegisterAsAdminRequest{
+		Email:       email,
+		Password:    password,
+		AdminSecret: st.Cfg.AdminSecret,
+	})
+	require.NoError(t, err)
+
+	respLogin, err := st.AuthClient.Login(ctx, &ssov1.LoginRequest{
+		Email:    email,
+		Password: password,
+	})
+	require.NoError(t, err)
+
+	return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+respLogin.GetToken())
+}
+
+func importUsers(ctx context.Context, t *testing.T, st *suite.Suite, dryRun bool, users ...*usersv1.User) *usersv1.ImportUsersResponse {
+	t.Helper()
+
+	stream, err := st.UsersClient.ImportUsers(ctx)
+	require.NoError(t, err)
+
+	for _, user := range users {
+		require.NoError(t, stream.Send(&usersv1.ImportUsersRequest{User: user, DryRun: dryRun}))
+	}
+
+	resp, err := stream.CloseAndRecv()
+	require.NoError(t, err)
+
+	return resp
+}
@@ -0,0 +1,153 @@
+package tests
+
+import (
+	"LibAssistant_sso/tests/suite"
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	usersv1 "LibAssistant_sso/protos/gen/go/LibAssistant/users"
+
+	ssov1 "github.com/MKode312/protos/gen/go/LibAssistant/sso"
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeleteUser_SoftDeleteAndRestore(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	adminCtx := adminContext(ctx, t, st)
+
+	email := gofakeit.Email()
+	password := randomFakePassword()
+
+	respReg, err := st.AuthClient.Register(ctx, &ssov1.RegisterRequest{
+		Email:    email,
+		Password: password,
+	})
+	require.NoError(t, err)
+
+	_, err = st.UsersClient.DeleteUser(adminCtx, &usersv1.DeleteUserRequest{UserId: respReg.GetUserId()})
+	require.NoError(t, err)
+
+	_, err = st.AuthClient.Login(ctx, &ssov1.LoginRequest{Email: email, Password: password})
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "invalid email or password")
+
+	_, err = st.AuthClient.IsAdmin(ctx, &ssov1.IsAdminRequest{UserId: respReg.GetUserId()})
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "user not found")
+
+	deleted := exportedUser(adminCtx, t, st, email, true)
+	require.NotNil(t, deleted)
+	assert.NotNil(t, deleted.GetDeletedAt())
+	assert.Nil(t, exportedUser(adminCtx, t, st, email, false))
+
+	_, err = st.UsersClient.RestoreUser(adminCtx, &usersv1.RestoreUserRequest{UserId: respReg.GetUserId()})
+	require.NoError(t, err)
+
+	event := nextUserEvent(adminCtx, t, st, &usersv1.WatchUserEventsRequest{Types: []string{"user.restored"}}, respReg.GetUserId())
+	assert.Equal(t, email, event.GetEmail())
+
+	_, err = st.AuthClient.Login(ctx, &ssov1.LoginRequest{Email: email, Password: password})
+	require.NoError(t, err)
+
+	restored := exportedUser(adminCtx, t, st, email, false)
+	require.NotNil(t, restored)
+	assert.Nil(t, restored.GetDeletedAt())
+	assert.NotNil(t, restored.GetLastLoginAt())
+}
+
+func TestDeleteUser_EmailRegisteredAgain(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	adminCtx := adminContext(ctx, t, st)
+
+	email := gofakeit.Email()
+
+	respReg, err := st.AuthClient.Register(ctx, &ssov1.RegisterRequest{
+		Email:    email,
+		Password: randomFakePassword(),
+	})
+	require.NoError(t, err)
+
+	_, err = st.UsersClient.DeleteUser(adminCtx, &usersv1.DeleteUserRequest{UserId: respReg.GetUserId()})
+	require.NoError(t, err)
+
+	respAgain, err := st.AuthClient.Register(ctx, &ssov1.RegisterRequest{
+		Email:    email,
+		Password: randomFakePassword(),
+	})
+	require.NoError(t, err)
+	assert.NotEqual(t, respReg.GetUserId(), respAgain.GetUserId())
+
+	_, err = st.UsersClient.RestoreUser(adminCtx, &usersv1.RestoreUserRequest{UserId: respReg.GetUserId()})
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "the email has been registered again since deletion")
+}
+
+func TestDeleteUser_NotFound(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	ctx = adminContext(ctx, t, st)
+
+	_, err := st.UsersClient.DeleteUser(ctx, &usersv1.DeleteUserRequest{UserId: randomFakeID()})
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "user not found")
+
+	_, err = st.UsersClient.RestoreUser(ctx, &usersv1.RestoreUserRequest{UserId: randomFakeID()})
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "deleted user not found")
+}
+
+func TestSetUserRole_PromoteAndDemote(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	adminCtx := adminContext(ctx, t, st)
+
+	respReg, err := st.AuthClient.Register(ctx, &ssov1.RegisterRequest{
+		Email:    gofakeit.Email(),
+		Password: randomFakePassword(),
+	})
+	require.NoError(t, err)
+
+	_, err = st.UsersClient.SetUserRole(adminCtx, &usersv1.SetUserRoleRequest{UserId: respReg.GetUserId(), Role: "admin"})
+	require.NoError(t, err)
+
+	respIsAdmin, err := st.AuthClient.IsAdmin(ctx, &ssov1.IsAdminRequest{UserId: respReg.GetUserId()})
+	require.NoError(t, err)
+	assert.True(t, respIsAdmin.GetIsAdmin())
+
+	_, err = st.UsersClient.SetUserRole(adminCtx, &usersv1.SetUserRoleRequest{UserId: respReg.GetUserId(), Role: "student"})
+	require.NoError(t, err)
+
+	respIsAdmin, err = st.AuthClient.IsAdmin(ctx, &ssov1.IsAdminRequest{UserId: respReg.GetUserId()})
+	require.NoError(t, err)
+	assert.False(t, respIsAdmin.GetIsAdmin())
+
+	_, err = st.UsersClient.SetUserRole(adminCtx, &usersv1.SetUserRoleRequest{UserId: respReg.GetUserId(), Role: "janitor"})
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "invalid role")
+}
+
+func exportedUser(ctx context.Context, t *testing.T, st *suite.Suite, email string, includeDeleted bool) *usersv1.User {
+	t.Helper()
+
+	stream, err := st.UsersClient.ExportUsers(ctx, &usersv1.ExportUsersRequest{IncludeDeleted: includeDeleted})
+	require.NoError(t, err)
+
+	var found *usersv1.User
+	for {
+		resp, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return found
+		}
+		require.NoError(t, err)
+
+		if resp.GetUser().GetEmail() == email {
+			found = resp.GetUser()
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package tests
+
+import (
+	"LibAssistant_sso/tests/suite"
+	"context"
+	"testing"
+
+	usersv1 "LibAssistant_sso/protos/gen/go/LibAssistant/users"
+
+	ssov1 "github.com/MKode312/protos/gen/go/LibAssistant/sso"
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchUserEvents_ResumeFromCursor(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	adminCtx := adminContext(ctx, t, st)
+
+	email := gofakeit.Email()
+	password := randomFakePassword()
+
+	respReg, err := st.AuthClient.Register(ctx, &ssov1.RegisterRequest{
+		Email:    email,
+		Password: password,
+	})
+	require.NoError(t, err)
+
+	registered := nextUserEvent(adminCtx, t, st, &usersv1.WatchUserEventsRequest{Types: []string{"user.registered"}}, respReg.GetUserId())
+	assert.Equal(t, email, registered.GetEmail())
+	assert.Equal(t, "student", registered.GetRole())
+	assert.NotEmpty(t, registered.GetId())
+
+	_, err = st.UsersClient.DisableUser(adminCtx, &usersv1.DisableUserRequest{UserId: respReg.GetUserId()})
+	require.NoError(t, err)
+
+	disabled := nextUserEvent(adminCtx, t, st, &usersv1.WatchUserEventsRequest{Cursor: registered.GetCursor()}, respReg.GetUserId())
+	assert.Equal(t, "user.disabled", disabled.GetType())
+	assert.Greater(t, disabled.GetCursor(), registered.GetCursor())
+
+	_, err = st.AuthClient.Login(ctx, &ssov1.LoginRequest{Email: email, Password: password})
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "account is disabled")
+
+	_, err = st.UsersClient.EnableUser(adminCtx, &usersv1.EnableUserRequest{UserId: respReg.GetUserId()})
+	require.NoError(t, err)
+
+	enabled := nextUserEvent(adminCtx, t, st, &usersv1.WatchUserEventsRequest{Cursor: disabled.GetCursor()}, respReg.GetUserId())
+	assert.Equal(t, "user.enabled", enabled.GetType())
+
+	_, err = st.AuthClient.Login(ctx, &ssov1.LoginRequest{Email: email, Password: password})
+	require.NoError(t, err)
+}
+
+func TestWatchUserEvents_RequiresAdmin(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	stream, err := st.UsersClient.WatchUserEvents(ctx, &usersv1.WatchUserEventsRequest{})
+	require.NoError(t, err)
+
+	_, err = stream.Recv()
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "authorization token is required")
+}
+
+// nextUserEvent watches from the request cursor and returns the first event of the given user.
+func nextUserEvent(ctx context.Context, t *testing.T, st *suite.Suite, req *usersv1.WatchUserEventsRequest, userID int64) *usersv1.UserEvent {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := st.UsersClient.WatchUserEvents(ctx, req)
+	require.NoError(t, err)
+
+	for {
+		event, err := stream.Recv()
+		require.NoError(t, err)
+
+		if event.GetUserId() == userID {
+			return event
+		}
+	}
+}